@@ -0,0 +1,172 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+// Copy returns a deep copy of the form's fields, transforms, examples and
+// named forms, so that the copy can be modified without changing the
+// original form. Validators themselves are shared between the forms.
+func (f *Form) Copy() *Form {
+	form := *f
+	form.Fields = make([]Field, len(f.Fields))
+	for i, field := range f.Fields {
+		form.Fields[i] = field.copy()
+	}
+	form.Transforms = append([]Transform(nil), f.Transforms...)
+	form.Examples = append([]FormExample(nil), f.Examples...)
+	if f.Forms != nil {
+		form.Forms = make(map[string]*Form, len(f.Forms))
+		for name, namedForm := range f.Forms {
+			form.Forms[name] = namedForm
+		}
+	}
+	return &form
+}
+
+func (f Field) copy() Field {
+	f.Validators = append([]Validator(nil), f.Validators...)
+	f.ValidatorDescriptions = append([]*ValidatorDescription(nil), f.ValidatorDescriptions...)
+	f.Examples = append([]FieldExample(nil), f.Examples...)
+	return f
+}
+
+// returns a copy of the form that only contains the fields for which keep
+// returns true. Transforms of removed fields are removed as well. Examples
+// are dropped as they might no longer be valid for the derived form.
+func (f *Form) filter(keep func(name string) bool) *Form {
+	form := f.Copy()
+	form.Examples = nil
+	fields := []Field{}
+	for _, field := range form.Fields {
+		if keep(field.Name) {
+			fields = append(fields, field)
+		}
+	}
+	transforms := []Transform{}
+	for _, transform := range form.Transforms {
+		if keep(transform.Field) {
+			transforms = append(transforms, transform)
+		}
+	}
+	form.Fields = fields
+	form.Transforms = transforms
+	return form
+}
+
+func nameSet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
+
+// Extend returns a copy of the form with the given fields added. Fields
+// that have the same name as an existing field replace that field.
+func (f *Form) Extend(fields ...Field) *Form {
+	form := f.Copy()
+	form.Examples = nil
+	for _, field := range fields {
+		found := false
+		for i, existingField := range form.Fields {
+			if existingField.Name == field.Name {
+				form.Fields[i] = field.copy()
+				found = true
+				break
+			}
+		}
+		if !found {
+			form.Fields = append(form.Fields, field.copy())
+		}
+	}
+	return form
+}
+
+// Pick returns a copy of the form that only contains the given fields.
+func (f *Form) Pick(names ...string) *Form {
+	set := nameSet(names)
+	return f.filter(func(name string) bool { return set[name] })
+}
+
+// Omit returns a copy of the form without the given fields.
+func (f *Form) Omit(names ...string) *Form {
+	set := nameSet(names)
+	return f.filter(func(name string) bool { return !set[name] })
+}
+
+func isOptionalValidator(validator Validator) bool {
+	switch validator.(type) {
+	case IsOptional, *IsOptional:
+		return true
+	}
+	return false
+}
+
+func isRequiredValidator(validator Validator) bool {
+	switch validator.(type) {
+	case IsRequired, *IsRequired:
+		return true
+	}
+	return false
+}
+
+// Partial returns a copy of the form in which the given fields (or all
+// fields if no names are given) are optional. Fields that are already
+// optional are left unchanged, i.e. they keep their default values.
+func (f *Form) Partial(names ...string) *Form {
+	set := nameSet(names)
+	form := f.Copy()
+	form.Examples = nil
+	for i, field := range form.Fields {
+		if len(names) > 0 && !set[field.Name] {
+			continue
+		}
+		validators := []Validator{}
+		for _, validator := range field.Validators {
+			if !isRequiredValidator(validator) {
+				validators = append(validators, validator)
+			}
+		}
+		if len(validators) == 0 || !isOptionalValidator(validators[0]) {
+			validators = append([]Validator{IsOptional{}}, validators...)
+		}
+		form.Fields[i].Validators = validators
+	}
+	return form
+}
+
+// Required returns a copy of the form in which the given fields (or all
+// fields if no names are given) are required. IsOptional validators (and
+// therefore their default values) are removed from these fields.
+func (f *Form) Required(names ...string) *Form {
+	set := nameSet(names)
+	form := f.Copy()
+	form.Examples = nil
+	for i, field := range form.Fields {
+		if len(names) > 0 && !set[field.Name] {
+			continue
+		}
+		validators := []Validator{}
+		for _, validator := range field.Validators {
+			if !isOptionalValidator(validator) {
+				validators = append(validators, validator)
+			}
+		}
+		if len(validators) == 0 || !isRequiredValidator(validators[0]) {
+			validators = append([]Validator{IsRequired{}}, validators...)
+		}
+		form.Fields[i].Validators = validators
+	}
+	return form
+}
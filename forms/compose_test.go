@@ -0,0 +1,94 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"testing"
+)
+
+func TestFormComposition(t *testing.T) {
+
+	userForm := &Form{
+		Strict: true,
+		Fields: []Field{
+			{
+				Name:       "name",
+				Validators: []Validator{IsString{MinLength: 2}},
+			},
+			{
+				Name:       "email",
+				Validators: []Validator{IsString{}},
+			},
+			{
+				Name:       "role",
+				Validators: []Validator{IsOptional{Default: "user"}, IsIn{Choices: []interface{}{"user", "admin"}}},
+			},
+		},
+	}
+
+	createForm := userForm.Omit("role")
+	updateForm := userForm.Partial()
+	adminForm := userForm.Required("role").Extend(Field{
+		Name:       "permissions",
+		Validators: []Validator{IsOptional{}, IsStringList{}},
+	})
+	emailForm := userForm.Pick("email")
+
+	// the original form must not be modified
+	if len(userForm.Fields) != 3 || len(userForm.Fields[0].Validators) != 1 || len(userForm.Fields[2].Validators) != 2 {
+		t.Fatalf("original form was modified")
+	}
+
+	for i, testCase := range []struct {
+		Form  *Form
+		Input map[string]interface{}
+		Valid bool
+	}{
+		{createForm, map[string]interface{}{"name": "Max", "email": "max@example.com"}, true},
+		{createForm, map[string]interface{}{"name": "Max", "email": "max@example.com", "role": "admin"}, false},
+		{updateForm, map[string]interface{}{"email": "max@example.com"}, true},
+		{updateForm, map[string]interface{}{"name": "M"}, false},
+		{adminForm, map[string]interface{}{"name": "Max", "email": "max@example.com"}, false},
+		{adminForm, map[string]interface{}{"name": "Max", "email": "max@example.com", "role": "admin", "permissions": []interface{}{"all"}}, true},
+		{emailForm, map[string]interface{}{"email": "max@example.com"}, true},
+		{emailForm, map[string]interface{}{"name": "Max", "email": "max@example.com"}, false},
+	} {
+		if _, err := testCase.Form.Validate(testCase.Input); testCase.Valid && err != nil {
+			t.Fatalf("test %d: expected no error but got %v", i, err)
+		} else if !testCase.Valid && err == nil {
+			t.Fatalf("test %d: expected an error", i)
+		}
+	}
+
+	// derived forms must serialize correctly
+	for i, form := range []*Form{createForm, updateForm, adminForm, emailForm} {
+		recoveredForm, err := CheckFormRoundTrip(form, nil)
+		if err != nil {
+			t.Fatalf("form %d: %v", i, err)
+		}
+		if len(recoveredForm.Fields) != len(form.Fields) {
+			t.Fatalf("form %d: expected %d fields", i, len(form.Fields))
+		}
+	}
+
+	// overriding a field replaces it
+	extendedForm := userForm.Extend(Field{Name: "name", Validators: []Validator{IsInteger{}}})
+
+	if len(extendedForm.Fields) != 3 {
+		t.Fatalf("expected the field to be replaced")
+	} else if _, err := extendedForm.Validate(map[string]interface{}{"name": 4, "email": "max@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,167 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// converts a value to JSON and back, as happens when a form description is
+// stored and loaded again
+func jsonRoundTrip(value interface{}, target interface{}) error {
+	if data, err := json.Marshal(value); err != nil {
+		return err
+	} else {
+		return json.Unmarshal(data, target)
+	}
+}
+
+// CheckRoundTrip serializes the given validator, converts the description to
+// JSON and back and creates a new validator from it using the given context.
+// It returns an error if any of these steps fails, if the new validator has
+// a different type or if it does not serialize to the same description.
+// Authors of custom validators can use it to check that their validators
+// can be stored in form descriptions.
+func CheckRoundTrip(validator Validator, context *FormDescriptionContext) (Validator, error) {
+
+	registry := context.registry()
+
+	description, err := registry.SerializeValidator(validator)
+
+	if err != nil {
+		return nil, err
+	}
+
+	loadedDescription := &ValidatorDescription{}
+
+	if err := jsonRoundTrip(description, loadedDescription); err != nil {
+		return nil, fmt.Errorf("cannot convert description to JSON: %v", err)
+	}
+
+	loadedValidator, err := ValidatorFromDescription(loadedDescription, context)
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot create validator from description: %v", err)
+	}
+
+	if !sameValidatorType(validator, loadedValidator) {
+		return nil, fmt.Errorf("expected a validator of type %T, got %T", validator, loadedValidator)
+	}
+
+	newDescription, err := registry.SerializeValidator(loadedValidator)
+
+	if err != nil {
+		return nil, fmt.Errorf("cannot serialize the new validator: %v", err)
+	}
+
+	// we compare the JSON representations, as numbers may change their type
+	var a, b interface{}
+
+	if err := jsonRoundTrip(description, &a); err != nil {
+		return nil, err
+	}
+
+	if err := jsonRoundTrip(newDescription, &b); err != nil {
+		return nil, err
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		return nil, fmt.Errorf("descriptions differ: %v vs. %v", a, b)
+	}
+
+	return loadedValidator, nil
+}
+
+// checks whether the validators have the same type, ignoring whether they
+// are pointers (as makers usually return pointers)
+func sameValidatorType(a, b Validator) bool {
+	ta, tb := reflect.TypeOf(a), reflect.TypeOf(b)
+	if ta == nil || tb == nil {
+		return ta == tb
+	}
+	if ta.Kind() == reflect.Ptr {
+		ta = ta.Elem()
+	}
+	if tb.Kind() == reflect.Ptr {
+		tb = tb.Elem()
+	}
+	return ta == tb
+}
+
+// CheckValidatorDefinitions creates a validator for each of the given
+// definitions and checks it with CheckRoundTrip. Configs can contain a config
+// for each validator name, otherwise an empty config is used. The errors are
+// returned in a FormError, keyed by the name of the validator.
+func CheckValidatorDefinitions(definitions map[string]ValidatorDefinition, configs map[string]map[string]interface{}, context *FormDescriptionContext) error {
+
+	names := []string{}
+
+	for name := range definitions {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	errors := map[string]interface{}{}
+
+	for _, name := range names {
+
+		config, ok := configs[name]
+
+		if !ok {
+			config = map[string]interface{}{}
+		}
+
+		validator, err := definitions[name].Maker(config, context)
+
+		if err != nil {
+			errors[name] = fmt.Errorf("cannot create validator: %v", err)
+			continue
+		}
+
+		if validatorName := context.registry().ValidatorName(validator); validatorName != name {
+			errors[name] = fmt.Errorf("validator is registered as '%s' but serialized as '%s'", name, validatorName)
+			continue
+		}
+
+		if _, err := CheckRoundTrip(validator, context); err != nil {
+			errors[name] = err
+		}
+	}
+
+	if len(errors) > 0 {
+		return MakeFormError("round trip failed for some validators", "FORM-ERROR", errors, nil)
+	}
+
+	return nil
+}
+
+// CheckFormRoundTrip converts the given form to JSON and loads it again using
+// FromConfig, returning the new form.
+func CheckFormRoundTrip(form *Form, context *FormDescriptionContext) (*Form, error) {
+
+	config := map[string]interface{}{}
+
+	if serializedForm, err := context.registry().SerializeForm(form); err != nil {
+		return nil, fmt.Errorf("cannot serialize form: %v", err)
+	} else if err := jsonRoundTrip(serializedForm, &config); err != nil {
+		return nil, fmt.Errorf("cannot convert form to JSON: %v", err)
+	}
+
+	return FromConfig(config, context)
+}
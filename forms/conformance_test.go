@@ -0,0 +1,147 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"testing"
+)
+
+// validators that cannot be created from an empty config
+var conformanceConfigs = map[string]map[string]interface{}{
+	"GeneralizeNumber": {"bucketSize": 10},
+	"Not":              {"validators": []interface{}{map[string]interface{}{"type": "IsString"}}},
+	"IsIn":             {"choices": []interface{}{"a", "b"}},
+	"IsNotIn":          {"choices": []interface{}{"a", "b"}},
+	"Or": {"options": []interface{}{
+		[]interface{}{map[string]interface{}{"type": "IsString", "config": map[string]interface{}{"minLength": 2}}},
+		[]interface{}{map[string]interface{}{"type": "IsInteger", "config": map[string]interface{}{"hasMin": true, "min": -4}}},
+	}},
+	"Switch": {
+		"key":     "type",
+		"default": []interface{}{map[string]interface{}{"type": "IsString"}},
+		"cases": map[string]interface{}{
+			"int": []interface{}{map[string]interface{}{"type": "IsInteger"}},
+		},
+	},
+	"IsStringMap": {
+		"form": map[string]interface{}{
+			"fields": []interface{}{
+				map[string]interface{}{
+					"name":       "foo",
+					"validators": []interface{}{map[string]interface{}{"type": "IsOptional", "config": map[string]interface{}{"default": "bar"}}},
+				},
+			},
+		},
+	},
+}
+
+func TestValidatorRoundTrip(t *testing.T) {
+	context := &FormDescriptionContext{Validators: Validators}
+	if err := CheckValidatorDefinitions(Validators, conformanceConfigs, context); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestNotSerializable(t *testing.T) {
+	for _, validator := range []Validator{
+		OnlyIf{Function: func(interface{}, map[string]interface{}) bool { return true }},
+		IsOptional{DefaultGenerator: func() interface{} { return 1 }},
+		IsStringMap{Coerce: struct{}{}},
+		IsList{Validators: []Validator{OnlyIf{}}},
+	} {
+		if _, err := SerializeValidator(validator); err == nil {
+			t.Errorf("expected an error for %T", validator)
+		}
+	}
+}
+
+func TestRoundTripTypeMismatch(t *testing.T) {
+	// a maker that returns a value of a different type
+	context := &FormDescriptionContext{
+		Validators: map[string]ValidatorDefinition{
+			"IsString": {
+				Maker: func(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+					return IsInteger{}, nil
+				},
+				Form: IsStringForm,
+			},
+		},
+	}
+	if _, err := CheckRoundTrip(IsString{}, context); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestFormRoundTrip(t *testing.T) {
+
+	form := &Form{
+		Name:        "test",
+		Strict:      true,
+		Description: "a test form",
+		Fields: []Field{
+			{
+				Name:        "name",
+				Description: "the name",
+				Validators: []Validator{
+					IsOptional{Default: "foo"},
+					IsString{MinLength: 2},
+					MatchesRegex{Source: "^[a-z]+$"},
+				},
+			},
+			{
+				Name: "kind",
+				Validators: []Validator{
+					IsIn{Choices: []interface{}{"a", "b"}},
+				},
+			},
+			{
+				Name: "value",
+				Validators: []Validator{
+					Switch{
+						Key:     "kind",
+						Default: []Validator{IsString{}},
+						Cases:   map[string][]Validator{"a": []Validator{IsInteger{HasMin: true, Min: -1}}},
+					},
+				},
+			},
+		},
+	}
+
+	loadedForm, err := CheckFormRoundTrip(form, &FormDescriptionContext{Validators: Validators})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !loadedForm.Strict || loadedForm.Name != "test" || loadedForm.Description != "a test form" || loadedForm.Fields[0].Description != "the name" {
+		t.Fatalf("form properties were not preserved")
+	}
+
+	for _, testCase := range []struct {
+		Input map[string]interface{}
+		Valid bool
+	}{
+		{map[string]interface{}{"kind": "a", "value": -1}, true},
+		{map[string]interface{}{"kind": "a", "value": -2}, false},
+		{map[string]interface{}{"kind": "b", "value": "foo"}, true},
+		{map[string]interface{}{"kind": "b", "value": "foo", "name": "A"}, false},
+	} {
+		_, err := form.Validate(testCase.Input)
+		_, loadedErr := loadedForm.Validate(testCase.Input)
+		if (err == nil) != testCase.Valid || (loadedErr == nil) != testCase.Valid {
+			t.Errorf("%v: unexpected results %v and %v", testCase.Input, err, loadedErr)
+		}
+	}
+}
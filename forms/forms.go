@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"github.com/kiprotect/go-helpers/errors"
 	"reflect"
+	"sort"
 	"strings"
 )
 
@@ -53,39 +54,95 @@ type Serializable interface {
 	Serialize() (map[string]interface{}, error)
 }
 
+// validators that contain other validators or forms implement this
+// interface, so that these are serialized with the same serializer
+type registrySerializable interface {
+	serialize(s *serializer) (map[string]interface{}, error)
+}
+
+// serializer serializes validators and forms using the validator names of a
+// registry. It keeps track of the forms that are being serialized, so that
+// recursive forms are serialized as references to named forms.
+type serializer struct {
+	registry *Registry
+	// the forms that are currently being serialized
+	forms map[*Form]bool
+	// the named forms that can be referenced in the current form
+	names map[string]*Form
+}
+
+func (r *Registry) serializer() *serializer {
+	return &serializer{
+		registry: r,
+		forms:    map[*Form]bool{},
+		names:    map[string]*Form{},
+	}
+}
+
+// SerializeValidator serializes the validator using the validator names of
+// DefaultRegistry.
 func SerializeValidator(validator Validator) (*ValidatorDescription, error) {
+	return DefaultRegistry.SerializeValidator(validator)
+}
+
+func SerializeValidators(validators []Validator) ([]*ValidatorDescription, error) {
+	return DefaultRegistry.SerializeValidators(validators)
+}
 
-	validatorType := GetType(validator)
+// SerializeValidator serializes the validator (including nested validators)
+// using the validator names of the registry.
+func (r *Registry) SerializeValidator(validator Validator) (*ValidatorDescription, error) {
+	return r.serializer().serializeValidator(validator)
+}
 
-	if serializableValidator, ok := validator.(Serializable); ok {
-		if config, err := serializableValidator.Serialize(); err != nil {
-			return nil, err
-		} else {
-			return &ValidatorDescription{
-				Type:   validatorType,
-				Config: config,
-			}, nil
-		}
-	} else {
-		config := map[string]interface{}{}
+func (r *Registry) SerializeValidators(validators []Validator) ([]*ValidatorDescription, error) {
+	return r.serializer().serializeValidators(validators)
+}
 
-		if err := Coerce(config, validator); err != nil {
-			return nil, fmt.Errorf("error serializing validator %v: %v", validator, err)
+// SerializeForm serializes the form using the validator names of the
+// registry. Forms serialized with encoding/json use DefaultRegistry.
+func (r *Registry) SerializeForm(form *Form) (map[string]interface{}, error) {
+	return r.serializer().serializeForm(form)
+}
+
+func (s *serializer) serializeValidator(validator Validator) (*ValidatorDescription, error) {
+
+	validatorType := s.registry.ValidatorName(validator)
+
+	var config map[string]interface{}
+	var err error
+
+	if registryValidator, ok := validator.(registrySerializable); ok {
+		config, err = registryValidator.serialize(s)
+	} else if serializableValidator, ok := validator.(Serializable); ok {
+		config, err = serializableValidator.Serialize()
+	} else {
+		// we use the JSON representation of the validator, which respects the
+		// names and omitempty flags of the json tags of its fields
+		config = map[string]interface{}{}
+
+		if data, jsonErr := json.Marshal(validator); jsonErr != nil {
+			err = fmt.Errorf("error serializing validator %s: %v", validatorType, jsonErr)
+		} else if jsonErr := json.Unmarshal(data, &config); jsonErr != nil {
+			err = fmt.Errorf("error serializing validator %s: %v", validatorType, jsonErr)
 		}
+	}
 
-		return &ValidatorDescription{
-			Type:   validatorType,
-			Config: config,
-		}, nil
+	if err != nil {
+		return nil, err
 	}
 
+	return &ValidatorDescription{
+		Type:   validatorType,
+		Config: config,
+	}, nil
 }
 
-func SerializeValidators(validators []Validator) ([]*ValidatorDescription, error) {
+func (s *serializer) serializeValidators(validators []Validator) ([]*ValidatorDescription, error) {
 	descriptions := []*ValidatorDescription{}
 	for _, validator := range validators {
 
-		description, err := SerializeValidator(validator)
+		description, err := s.serializeValidator(validator)
 
 		if err != nil {
 			return nil, err
@@ -94,11 +151,108 @@ func SerializeValidators(validators []Validator) ([]*ValidatorDescription, error
 		descriptions = append(descriptions, description)
 	}
 	return descriptions, nil
+}
 
+// returns the name under which the form can be referenced (if any)
+func (s *serializer) formName(form *Form) (string, bool) {
+	names := []string{}
+	for name, namedForm := range s.names {
+		if namedForm == form {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "", false
+	}
+	sort.Strings(names)
+	return names[0], true
+}
+
+// the form attributes that are serialized via their json tags
+type formAttributes Form
+
+func (s *serializer) serializeForm(f *Form) (map[string]interface{}, error) {
+
+	if s.forms[f] {
+		// the form contains itself, which we can only serialize as a
+		// reference to a named form
+		if name, ok := s.formName(f); ok {
+			return map[string]interface{}{"$ref": name}, nil
+		}
+		return nil, fmt.Errorf("cannot serialize recursive form, recursive forms must be named forms")
+	}
+
+	s.forms[f] = true
+	defer delete(s.forms, f)
+
+	// the named forms of the form can be referenced by the form and by
+	// nested forms (which can declare named forms with the same name)
+	for name, namedForm := range f.Forms {
+		parentForm, hasParentForm := s.names[name]
+		s.names[name] = namedForm
+		defer func(name string) {
+			if hasParentForm {
+				s.names[name] = parentForm
+			} else {
+				delete(s.names, name)
+			}
+		}(name)
+	}
+
+	// we serialize the fields and named forms ourselves
+	form := *f
+	form.Fields = nil
+	form.Forms = nil
+
+	config := map[string]interface{}{}
+
+	if data, err := json.Marshal((*formAttributes)(&form)); err != nil {
+		return nil, err
+	} else if err := json.Unmarshal(data, &config); err != nil {
+		return nil, err
+	}
+
+	fields := make([]interface{}, len(f.Fields))
+
+	for i := range f.Fields {
+		if field, err := f.Fields[i].serialize(s); err != nil {
+			return nil, err
+		} else {
+			fields[i] = field
+		}
+	}
+
+	config["fields"] = fields
+
+	if len(f.Forms) > 0 {
+		forms := map[string]interface{}{}
+		for name, namedForm := range f.Forms {
+			if serializedForm, err := s.serializeForm(namedForm); err != nil {
+				return nil, err
+			} else {
+				forms[name] = serializedForm
+			}
+		}
+		config["forms"] = forms
+	}
+
+	return config, nil
+}
+
+func (f *Form) MarshalJSON() ([]byte, error) {
+	if serializedForm, err := DefaultRegistry.SerializeForm(f); err != nil {
+		return nil, err
+	} else {
+		return json.Marshal(serializedForm)
+	}
 }
 
 func (f *Field) Serialize() (map[string]interface{}, error) {
-	if descriptions, err := SerializeValidators(f.Validators); err != nil {
+	return f.serialize(DefaultRegistry.serializer())
+}
+
+func (f *Field) serialize(s *serializer) (map[string]interface{}, error) {
+	if descriptions, err := s.serializeValidators(f.Validators); err != nil {
 		return nil, err
 	} else {
 		m := map[string]interface{}{
@@ -113,6 +267,18 @@ func (f *Field) Serialize() (map[string]interface{}, error) {
 		if f.Global {
 			m["global"] = true
 		}
+
+		if len(f.Examples) > 0 {
+			m["examples"] = f.Examples
+		}
+
+		if len(f.Aliases) > 0 {
+			m["aliases"] = f.Aliases
+		}
+
+		if f.Deprecated {
+			m["deprecated"] = true
+		}
 		return m, nil
 	}
 }
@@ -126,6 +292,42 @@ type Field struct {
 	Global                bool                    `json:"global,omitempty"`
 	Description           string                  `json:"description,omitempty"`
 	Examples              []FieldExample          `json:"examples,omitempty"`
+	// Aliases are alternative input keys for the field (e.g. old names)
+	Aliases []string `json:"aliases,omitempty"`
+	// Deprecated fields are accepted but generate a warning (if warnings are
+	// collected, see ValidateWithResult)
+	Deprecated bool `json:"deprecated,omitempty"`
+}
+
+// returns true if the field validates the input with the given key
+func (f *Field) matches(key string) bool {
+	return f.Name == key || fieldRoot(f.Name) == key || f.hasAlias(key)
+}
+
+func (f *Field) hasAlias(key string) bool {
+	for _, alias := range f.Aliases {
+		if alias == key {
+			return true
+		}
+	}
+	return false
+}
+
+// returns the input value of the field and the alias it was found under (if
+// any). Providing a value under more than one name is an error.
+func (f *Field) lookup(input map[string]interface{}) (interface{}, string, error) {
+	value, found := input[f.Name]
+	key := f.Name
+	alias := ""
+	for _, fieldAlias := range f.Aliases {
+		if aliasValue, ok := input[fieldAlias]; ok {
+			if found {
+				return nil, "", fmt.Errorf("'%s' and '%s' cannot both be given", key, fieldAlias)
+			}
+			value, found, key, alias = aliasValue, true, fieldAlias, fieldAlias
+		}
+	}
+	return value, alias, nil
 }
 
 type FieldExample struct {
@@ -186,6 +388,8 @@ type Form struct {
 	ErrorMsg                string                   `json:"errorMsg,omitempty"`
 	Description             string                   `json:"description,omitempty"`
 	Examples                []FormExample            `json:"examples,omitempty"`
+	Forms                   map[string]*Form         `json:"forms,omitempty"`
+	UnknownKeys             UnknownKeyPolicy         `json:"unknownKeys,omitempty" coerce:"convert"`
 }
 
 type FormExample struct {
@@ -264,16 +468,48 @@ func (f *Form) ValidateUpdate(inputs map[string]interface{}) (values map[string]
 }
 
 func (f *Form) validate(inputs map[string]interface{}, update bool, context map[string]interface{}) (values map[string]interface{}, validationError error) {
+	return f.validateFields(inputs, update, context, "")
+}
+
+// validates the inputs with the form. If only is given, only the validators
+// of the fields with that name (or alias or path root) are run, the other
+// inputs are used as values as they are and only the errors of the field
+// are returned.
+func (f *Form) validateFields(inputs map[string]interface{}, update bool, context map[string]interface{}, only string) (values map[string]interface{}, validationError error) {
 
 	errors := make(map[string]interface{})
 	values = make(map[string]interface{})
 	var sanitizedInput map[string]interface{}
 	if f.SanitizeKeys {
 		sanitizedInput = sanitizeURLValues(inputs)
+		only = strings.ToLower(only)
 	} else {
 		sanitizedInput = inputs
 	}
 
+	// the names under which the validated field might appear
+	onlyNames := map[string]bool{}
+
+	if only != "" {
+		onlyNames[only] = true
+		for _, field := range f.Fields {
+			if field.matches(only) {
+				onlyNames[field.Name] = true
+				onlyNames[fieldRoot(field.Name)] = true
+				for _, alias := range field.Aliases {
+					onlyNames[alias] = true
+				}
+			}
+		}
+		// other fields can depend on the values of other fields, which we
+		// therefore copy without validating them
+		for key, value := range sanitizedInput {
+			if !onlyNames[key] {
+				values[key] = value
+			}
+		}
+	}
+
 	setError := func(key string, err error) {
 		if _, ok := err.(*FormError); ok {
 			// form errors we include in their structured form
@@ -284,27 +520,115 @@ func (f *Form) validate(inputs map[string]interface{}, update bool, context map[
 		}
 	}
 
+	policy := f.unknownKeyPolicy(context)
+
+	if f.UnknownKeys != "" {
+		// nested forms without a policy inherit the policy of this form
+		if context == nil {
+			context = map[string]interface{}{}
+		}
+		parentPolicy, hasParentPolicy := context[unknownKeysContextKey]
+		context[unknownKeysContextKey] = f.UnknownKeys
+		defer func() {
+			if hasParentPolicy {
+				context[unknownKeysContextKey] = parentPolicy
+			} else {
+				delete(context, unknownKeysContextKey)
+			}
+		}()
+	}
+
+	var warnings *warningCollector
+
+	if collector, ok := context[warningsContextKey].(*warningCollector); ok {
+		// we collect the warnings of this form with the path of the field
+		// that is currently validated by the parent form (if any)
+		warnings = collector.nested()
+		context[warningsContextKey] = warnings
+		defer func() { context[warningsContextKey] = collector }()
+	}
+
+	// warnings are only emitted if they are collected (see ValidateWithResult)
+	warn := func(key, message string) {
+		if warnings != nil {
+			warnings.add(key, message)
+		}
+	}
+
 	var err error
 	var value interface{}
+	writer := makePathWriter()
 	for _, field := range f.Fields {
 		keys := []string{field.Name}
+		var paths []pathValue
 
-		if field.Name == "*" {
+		if only != "" && field.Name != "*" && !field.matches(only) {
+			continue
+		}
+
+		if field.Name == "*" && only != "" {
+			keys = []string{only}
+		} else if field.Name == "*" {
 			// this is a wildcard field that should be applied to all
 			// input values (e.g. useful for global validators)
 			keys = make([]string, 0)
 			for k, _ := range sanitizedInput {
 				keys = append(keys, k)
 			}
+		} else if _, ok := sanitizedInput[field.Name]; !ok && isFieldPath(field.Name) {
+			// this field addresses nested values (e.g. "items[*].price"), we
+			// only treat it as a path if there's no key with the same name
+			if segments, ok := parseFieldPath(field.Name); ok {
+				keys = make([]string, 0)
+				for _, pathValue := range expandFieldPath(sanitizedInput, segments, nil) {
+					keys = append(keys, formatFieldPath(pathValue.Path))
+					paths = append(paths, pathValue)
+				}
+			}
 		}
 
-		for _, key := range keys {
+		for i, key := range keys {
 
-			value = sanitizedInput[key]
+			var path []interface{}
+
+			if paths != nil && paths[i].Error != nil {
+				// a parent value of the path has the wrong type
+				setError(key, paths[i].Error)
+				continue
+			} else if paths != nil {
+				path = paths[i].Path
+				value = paths[i].Value
+			} else if field.Name != "*" && len(field.Aliases) > 0 {
+				var alias string
+				if value, alias, err = field.lookup(sanitizedInput); err != nil {
+					setError(key, err)
+					continue
+				} else if alias != "" {
+					warn(alias, fmt.Sprintf("field name is an alias of '%s'", field.Name))
+				}
+			} else {
+				value = sanitizedInput[key]
+			}
+
+			if field.Deprecated && value != nil {
+				warn(key, "field is deprecated")
+			}
+
+			if warnings != nil {
+				warnings.field = key
+			}
+
+			setValue := func(value interface{}) {
+				if path == nil {
+					values[key] = value
+				} else if value != nil {
+					writer.set(values, path, value)
+				}
+			}
 
 			// if no validators are given, we simply copy the raw value
 			if len(field.Validators) == 0 {
-				values[key] = value
+				setValue(value)
 			}
 
 			for _, validator := range field.Validators {
@@ -326,12 +650,12 @@ func (f *Form) validate(inputs map[string]interface{}, update bool, context map[
 				if value == nil {
 					break //if the value is nil we break out of the processing
 				}
-				values[key] = value
+				setValue(value)
 			}
 		}
 	}
 
-	if len(errors) == 0 {
+	if len(errors) == 0 && only == "" {
 		for _, transform := range f.Transforms {
 			for _, function := range transform.Functions {
 				value, err := function(values[transform.Field], values)
@@ -351,7 +675,29 @@ func (f *Form) validate(inputs map[string]interface{}, update bool, context map[
 	// any field errors.
 
 	hasError := false
-	if f.Validator != nil && len(errors) == 0 {
+	var formLevelError error
+	if f.Validator != nil && len(errors) == 0 && only != "" {
+		// the form validator expects validated values, so we validate all
+		// fields (without collecting warnings again) and only add its errors,
+		// which means it does not run if any other field is invalid
+		quietContext := map[string]interface{}{}
+		for key, value := range context {
+			if key != warningsContextKey {
+				quietContext[key] = value
+			}
+		}
+		if _, err := f.validateFields(inputs, update, quietContext, ""); err != nil {
+			if formError, ok := err.(*FormError); ok && len(formError.Errors()) > 0 {
+				for key, fieldError := range formError.Errors() {
+					errors[key] = fieldError
+				}
+			} else {
+				// the form validator did not add any field errors, so we
+				// return its error as it is (as a form-level error)
+				formLevelError = err
+			}
+		}
+	} else if f.Validator != nil && len(errors) == 0 {
 		// if there's a validator function defined we call it
 		if err := f.Validator(values, setError); err != nil {
 			errorMessage = err.Error()
@@ -359,24 +705,61 @@ func (f *Form) validate(inputs map[string]interface{}, update bool, context map[
 		}
 	}
 
-	if f.Strict {
+	switch policy {
+	case RejectUnknownKeys:
 		for k, _ := range sanitizedInput {
-			found := false
-			for _, field := range f.Fields {
-				if field.Name == k {
-					found = true
-					break
-				}
+			if only != "" && k != only {
+				continue
 			}
-			if !found {
-				setError(k, fmt.Errorf("field is unexpected"))
+			if !f.knowsKey(k) {
+				setError(k, f.unknownKeyError(k))
+			} else {
+				f.checkUnknownPathKeys(k, sanitizedInput[k], setError)
+			}
+		}
+	case AllowUnknownKeys:
+		for k, v := range sanitizedInput {
+			if !f.knowsKey(k) {
+				values[k] = v
 			}
 		}
 	}
 
+	if only != "" {
+		// we only return the errors of the validated field
+		fieldErrors := map[string]interface{}{}
+		for key, err := range errors {
+			if onlyNames[key] || onlyNames[fieldRoot(key)] {
+				fieldErrors[key] = err
+			}
+		}
+		if len(fieldErrors) > 0 {
+			validationError = f.makeError(errorMessage, fieldErrors)
+		} else if formLevelError != nil {
+			validationError = formLevelError
+		}
+		return
+	}
+
 	if len(errors) > 0 || hasError {
 		validationError = f.makeError(errorMessage, errors)
 	}
 
 	return
 }
+
+// ValidateField validates a single field with the given value, e.g. while a
+// user edits a form. The current values of the other fields are not
+// validated but are available to the field validators (e.g. Switch). If the
+// field is valid and the form has a validator, all fields are validated and
+// the form validator is run if they are valid. Only errors of the given
+// field and form-level errors of the form validator are returned.
+func (f *Form) ValidateField(name string, value interface{}, currentValues map[string]interface{}, context map[string]interface{}) error {
+	inputs := map[string]interface{}{}
+	for key, currentValue := range currentValues {
+		inputs[key] = currentValue
+	}
+	inputs[name] = value
+	_, err := f.validateFields(inputs, false, context, name)
+	return err
+}
@@ -17,7 +17,9 @@ package forms
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 )
 
 func testCases(t *testing.T, form Form, testCases []map[string]interface{}, valid bool) {
@@ -248,3 +250,213 @@ func TestIsList(t *testing.T) {
 	testCases(t, form, validTestCases, true)
 	testCases(t, form, invalidTestCases, false)
 }
+
+func TestFieldAliases(t *testing.T) {
+
+	form := &Form{
+		Strict: true,
+		Fields: []Field{
+			{
+				Name:       "firstName",
+				Aliases:    []string{"first_name", "fname"},
+				Validators: []Validator{IsString{}},
+			},
+			{
+				Name:       "nickname",
+				Deprecated: true,
+				Validators: []Validator{IsOptional{}, IsString{}},
+			},
+		},
+	}
+
+	recoveredForm, err := CheckFormRoundTrip(form, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(recoveredForm.Fields[0].Aliases) != 2 || !recoveredForm.Fields[1].Deprecated {
+		t.Fatalf("aliases or deprecation flag were not serialized")
+	}
+
+	for _, f := range []*Form{form, recoveredForm} {
+		for _, input := range []map[string]interface{}{
+			{"firstName": "Max"},
+			{"first_name": "Max"},
+			{"fname": "Max", "nickname": "maxi"},
+		} {
+			if values, err := f.Validate(input); err != nil {
+				t.Fatalf("expected no error but got %v", err)
+			} else if values["firstName"] != "Max" {
+				t.Fatalf("expected the value under the canonical name but got %v", values)
+			} else if _, ok := values["first_name"]; ok {
+				t.Fatalf("did not expect the alias in the values")
+			}
+		}
+
+		for _, input := range []map[string]interface{}{
+			{"firstName": "Max", "first_name": "Max"},
+			{"first_name": "Max", "fname": "Max"},
+			{"firstname": "Max"},
+		} {
+			if _, err := f.Validate(input); err == nil {
+				t.Fatalf("expected an error for %v", input)
+			}
+		}
+	}
+}
+
+type countingValidator struct {
+	Count *int
+}
+
+func (c countingValidator) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+	*c.Count++
+	return input, nil
+}
+
+func TestValidateField(t *testing.T) {
+
+	count := 0
+
+	form := &Form{
+		Fields: []Field{
+			{
+				Name:       "type",
+				Validators: []Validator{IsIn{Choices: []interface{}{"email", "phone"}}},
+			},
+			{
+				Name: "contact",
+				Validators: []Validator{
+					Switch{
+						Key: "type",
+						Cases: map[string][]Validator{
+							"email": {IsString{MinLength: 3}},
+							"phone": {IsInteger{}},
+						},
+					},
+				},
+			},
+			{
+				Name:       "password",
+				Validators: []Validator{countingValidator{Count: &count}, IsString{MinLength: 8}},
+			},
+			{
+				Name:       "passwordRepeat",
+				Validators: []Validator{IsString{}},
+			},
+		},
+		Validator: func(values map[string]interface{}, addError ErrorAdder) error {
+			if values["password"] != values["passwordRepeat"] {
+				addError("passwordRepeat", fmt.Errorf("passwords do not match"))
+			}
+			return nil
+		},
+	}
+
+	current := map[string]interface{}{
+		"type":     "phone",
+		"password": "foobarbaz",
+	}
+
+	// without a form validator the other fields are not validated
+	formWithoutValidator := &Form{Fields: form.Fields}
+
+	if err := formWithoutValidator.ValidateField("contact", 4, current, nil); err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+
+	if err := formWithoutValidator.ValidateField("contact", "max@example.com", current, nil); err == nil {
+		t.Fatalf("expected an error for the phone case")
+	} else if errors := err.(*FormError).Errors(); len(errors) != 1 || errors["contact"] == nil {
+		t.Fatalf("expected only an error for the contact field but got %v", errors)
+	}
+
+	current["type"] = "email"
+
+	if err := formWithoutValidator.ValidateField("contact", "max@example.com", current, nil); err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+
+	if count != 0 {
+		t.Fatalf("validators of other fields must not be run")
+	}
+
+	current["contact"] = "max@example.com"
+
+	if err := form.ValidateField("passwordRepeat", "bar", current, nil); err == nil {
+		t.Fatalf("expected an error from the form validator")
+	} else if errors := err.(*FormError).Errors(); len(errors) != 1 || errors["passwordRepeat"] == nil {
+		t.Fatalf("expected only an error for the passwordRepeat field but got %v", errors)
+	}
+
+	if err := form.ValidateField("passwordRepeat", "foobarbaz", current, nil); err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+
+	// the form validator only runs if all fields are valid
+	current["password"] = "foo"
+
+	if err := form.ValidateField("passwordRepeat", "bar", current, nil); err != nil {
+		t.Fatalf("expected no error but got %v", err)
+	}
+
+	count = 0
+
+	if err := form.ValidateField("password", "foo", current, nil); err == nil {
+		t.Fatalf("expected an error for a short password")
+	} else if count != 1 {
+		t.Fatalf("expected the password validators to run once")
+	}
+}
+
+func TestValidateFieldWithFormValidator(t *testing.T) {
+
+	form := &Form{
+		Fields: []Field{
+			{
+				Name:       "start",
+				Validators: []Validator{IsTime{}},
+			},
+			{
+				Name:       "end",
+				Validators: []Validator{IsTime{}},
+			},
+		},
+		Validator: func(values map[string]interface{}, addError ErrorAdder) error {
+			// this only works with validated values
+			if values["end"].(time.Time).Before(values["start"].(time.Time)) {
+				return fmt.Errorf("end is before start")
+			}
+			return nil
+		},
+	}
+
+	for _, testCase := range []struct {
+		Start string
+		End   string
+		// the expected error message and field errors (if any)
+		Message string
+		Fields  int
+	}{
+		{"2024-01-01T00:00:00Z", "2024-01-02T00:00:00Z", "", 0},
+		// errors without field errors are returned as form-level errors
+		{"2024-01-03T00:00:00Z", "2024-01-02T00:00:00Z", "end is before start", 0},
+		// the form validator is not run if another field is invalid
+		{"foo", "2024-01-02T00:00:00Z", "", 0},
+		{"2024-01-01T00:00:00Z", "bar", "invalid input data", 1},
+	} {
+		err := form.ValidateField("end", testCase.End, map[string]interface{}{"start": testCase.Start}, nil)
+		if testCase.Message == "" && err != nil {
+			t.Fatalf("%s - %s: expected no error but got %v", testCase.Start, testCase.End, err)
+		} else if testCase.Message == "" {
+			continue
+		} else if formError, ok := err.(*FormError); !ok {
+			t.Fatalf("%s - %s: expected a form error but got %v", testCase.Start, testCase.End, err)
+		} else if !strings.HasPrefix(formError.Message(), testCase.Message) || len(formError.Errors()) != testCase.Fields {
+			t.Fatalf("%s - %s: expected '%s' with %d field error(s) but got %v", testCase.Start, testCase.End, testCase.Message, testCase.Fields, err)
+		} else if testCase.Fields > 0 && formError.Errors()["end"] == nil {
+			t.Fatalf("%s - %s: expected an error for the end field but got %v", testCase.Start, testCase.End, err)
+		}
+	}
+}
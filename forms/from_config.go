@@ -16,6 +16,9 @@ package forms
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 )
 
 type IsValidConfig struct {
@@ -92,6 +95,20 @@ var FieldForm = Form{
 				IsString{},
 			},
 		},
+		{
+			Name: "description",
+			Validators: []Validator{
+				IsOptional{},
+				IsString{},
+			},
+		},
+		{
+			Name: "global",
+			Validators: []Validator{
+				IsOptional{Default: false},
+				IsBoolean{},
+			},
+		},
 		{
 			Name: "examples",
 			Validators: []Validator{
@@ -105,6 +122,20 @@ var FieldForm = Form{
 				},
 			},
 		},
+		{
+			Name: "aliases",
+			Validators: []Validator{
+				IsOptional{},
+				IsStringList{},
+			},
+		},
+		{
+			Name: "deprecated",
+			Validators: []Validator{
+				IsOptional{Default: false},
+				IsBoolean{},
+			},
+		},
 		{
 			Name: "validators",
 			Validators: []Validator{
@@ -133,6 +164,15 @@ var FormForm = Form{
 				IsBoolean{},
 			},
 		},
+		{
+			Name: "unknownKeys",
+			Validators: []Validator{
+				IsOptional{},
+				IsIn{
+					Choices: []interface{}{"allow", "strip", "reject"},
+				},
+			},
+		},
 		{
 			Name: "sanitizeKeys",
 			Validators: []Validator{
@@ -197,9 +237,53 @@ var FormForm = Form{
 				IsString{},
 			},
 		},
+		{
+			Name: "description",
+			Validators: []Validator{
+				IsOptional{},
+				IsString{},
+			},
+		},
+		{
+			// named forms that can be referenced via {"$ref": "name"}
+			Name: "forms",
+			Validators: []Validator{
+				IsOptional{},
+				IsStringMap{},
+			},
+		},
+	},
+}
+
+// FormReferenceForm validates references to named forms.
+var FormReferenceForm = Form{
+	Strict: true,
+	Fields: []Field{
+		{
+			Name: "$ref",
+			Validators: []Validator{
+				IsString{MinLength: 1},
+			},
+		},
 	},
 }
 
+// IsFormDescription accepts either a form description or a reference to a
+// named form (e.g. {"$ref": "Address"}).
+type IsFormDescription struct {
+}
+
+func (i IsFormDescription) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+	config, ok := input.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("not a string map")
+	}
+	if _, ok := config["$ref"]; ok {
+		return FormReferenceForm.Validate(config)
+	}
+	return FormForm.Validate(config)
+}
+
 type ValidatorMaker func(map[string]interface{}, *FormDescriptionContext) (Validator, error)
 
 type ValidatorDescription struct {
@@ -216,29 +300,146 @@ type PreprocessorDescription struct {
 	Type string `json:"type"`
 }
 
+// FormDescriptionContext determines which validators are available when
+// creating forms from descriptions. If Registry is set it is used, otherwise
+// the Validators map is used. If neither is set, DefaultRegistry is used.
+// Validator configs are validated strictly (i.e. unknown keys are rejected)
+// unless IgnoreUnknownConfigKeys is set. Forms contains the named forms that
+// can be referenced in descriptions via {"$ref": "name"}. If set,
+// OnDeprecatedName is called whenever a deprecated validator alias is used.
 type FormDescriptionContext struct {
-	Validators map[string]ValidatorDefinition
+	Validators              map[string]ValidatorDefinition
+	Registry                *Registry
+	Forms                   map[string]*Form
+	IgnoreUnknownConfigKeys bool
+	OnDeprecatedName        func(name, replacement string)
+}
+
+// returns the registry whose validator names are used for serialization
+func (c *FormDescriptionContext) registry() *Registry {
+	if c != nil && c.Registry != nil {
+		return c.Registry
+	}
+	return DefaultRegistry
+}
+
+// returns the named form with the given name
+func (c *FormDescriptionContext) form(name string) (*Form, error) {
+	if c != nil {
+		if form, ok := c.Forms[name]; ok {
+			return form, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown form: '%s'", name)
+}
+
+// returns the canonical name and the definition of the given validator type
+func (c *FormDescriptionContext) lookup(validatorType string) (string, ValidatorDefinition, bool) {
+
+	registry := c.Registry
+
+	if registry == nil && c.Validators != nil {
+		definition, ok := c.Validators[validatorType]
+		return validatorType, definition, ok
+	} else if registry == nil {
+		registry = DefaultRegistry
+	}
+
+	name, deprecated, ok := registry.Resolve(validatorType)
+
+	if !ok {
+		return "", ValidatorDefinition{}, false
+	}
+
+	if deprecated && c.OnDeprecatedName != nil {
+		c.OnDeprecatedName(validatorType, name)
+	}
+
+	definition, ok := registry.Lookup(name)
+
+	return name, definition, ok
+}
+
+// ConfigErrorCode is the code of errors in form and validator descriptions.
+const ConfigErrorCode = "CONFIG-ERROR"
+
+// appends a key to a config path, using brackets for list indexes
+func joinConfigPath(path, key string) string {
+	if _, err := strconv.Atoi(key); err == nil {
+		return path + "[" + key + "]"
+	} else if path == "" || strings.HasPrefix(key, "[") {
+		return path + key
+	}
+	return path + "." + key
+}
+
+// adds the (nested) errors of a form error to a flat map of config paths
+func flattenConfigErrors(path string, err error, errors map[string]interface{}) {
+	if formError, ok := err.(*FormError); ok {
+		if data, ok := formError.Data().(map[string]interface{}); ok && len(data) > 0 {
+			for key, value := range data {
+				if valueError, ok := value.(error); ok {
+					flattenConfigErrors(joinConfigPath(path, key), valueError, errors)
+				} else {
+					errors[joinConfigPath(path, key)] = value
+				}
+			}
+			return
+		}
+	}
+	errors[path] = err.Error()
+}
+
+// makeConfigError returns a config error whose messages are keyed by their
+// full path in the description (e.g. "fields[3].validators[1].config.minLength").
+// Nested form and config errors are flattened and prefixed with the given path.
+func makeConfigError(path string, err error) error {
+	errors := map[string]interface{}{}
+	flattenConfigErrors(path, err, errors)
+	return MakeFormError("invalid config", ConfigErrorCode, errors, nil)
 }
 
 func ValidatorFromDescription(config *ValidatorDescription, context *FormDescriptionContext) (Validator, error) {
-	if definition, ok := context.Validators[config.Type]; !ok {
-		return nil, fmt.Errorf("unknown validator type: '%s'", config.Type)
-	} else {
-		return definition.Maker(config.Config, context)
+
+	if context == nil {
+		context = &FormDescriptionContext{Registry: DefaultRegistry}
+	}
+
+	_, definition, ok := context.lookup(config.Type)
+
+	if !ok {
+		return nil, makeConfigError("type", fmt.Errorf("unknown validator type: '%s'", config.Type))
 	}
+
+	if !context.IgnoreUnknownConfigKeys {
+		// we validate the config with a strict copy of the validator form
+		form := definition.Form
+		form.Strict = true
+		if _, err := form.Validate(config.Config); err != nil {
+			return nil, makeConfigError("config", err)
+		}
+	}
+
+	validator, err := definition.Maker(config.Config, context)
+
+	if err != nil {
+		return nil, makeConfigError("config", err)
+	}
+
+	return validator, nil
 }
 
 func (f *Form) Initialize(context *FormDescriptionContext) error {
 
 	fields := []Field{}
 
-	for _, field := range f.Fields {
+	for i, field := range f.Fields {
 
 		validators := []Validator{}
 
-		for _, validatorDescription := range field.ValidatorDescriptions {
+		for j, validatorDescription := range field.ValidatorDescriptions {
 			if validator, err := ValidatorFromDescription(validatorDescription, context); err != nil {
-				return err
+				return makeConfigError(fmt.Sprintf("fields[%d].validators[%d]", i, j), err)
 			} else {
 				validators = append(validators, validator)
 			}
@@ -255,13 +456,89 @@ func (f *Form) Initialize(context *FormDescriptionContext) error {
 	return nil
 }
 
+// declares the given named forms and returns a context in which they can be
+// referenced. All forms are allocated before they are initialized, so named
+// forms can reference each other and themselves.
+func (f *Form) declareForms(configs map[string]interface{}, context *FormDescriptionContext) (*FormDescriptionContext, error) {
+
+	formsContext := &FormDescriptionContext{}
+
+	if context != nil {
+		contextCopy := *context
+		formsContext = &contextCopy
+	}
+
+	forms := map[string]*Form{}
+
+	for name, form := range formsContext.Forms {
+		forms[name] = form
+	}
+
+	names := make([]string, 0, len(configs))
+
+	f.Forms = map[string]*Form{}
+
+	for name := range configs {
+		form := &Form{}
+		forms[name] = form
+		f.Forms[name] = form
+		names = append(names, name)
+	}
+
+	formsContext.Forms = forms
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := joinConfigPath("forms", name)
+		config, ok := configs[name].(map[string]interface{})
+		if !ok {
+			return nil, makeConfigError(path, fmt.Errorf("not a string map"))
+		}
+		if params, err := FormForm.Validate(config); err != nil {
+			return nil, makeConfigError(path, err)
+		} else if err := f.Forms[name].fromParams(params, formsContext); err != nil {
+			return nil, makeConfigError(path, err)
+		}
+		if f.Forms[name].Name == "" {
+			f.Forms[name].Name = name
+		}
+	}
+
+	return formsContext, nil
+}
+
+// initializes the form from validated FormForm parameters
+func (f *Form) fromParams(params map[string]interface{}, context *FormDescriptionContext) error {
+
+	formParams := map[string]interface{}{}
+
+	for key, value := range params {
+		if key != "forms" {
+			formParams[key] = value
+		}
+	}
+
+	if err := FormForm.Coerce(f, formParams); err != nil {
+		return err
+	}
+
+	if forms, ok := params["forms"].(map[string]interface{}); ok && len(forms) > 0 {
+		var err error
+		if context, err = f.declareForms(forms, context); err != nil {
+			return err
+		}
+	}
+
+	return f.Initialize(context)
+}
+
 func FromConfig(config map[string]interface{}, context *FormDescriptionContext) (*Form, error) {
 	form := &Form{}
 
 	if params, err := FormForm.Validate(config); err != nil {
-		return nil, err
-	} else if err := FormForm.Coerce(form, params); err != nil {
-		return nil, err
+		return nil, makeConfigError("", err)
+	} else {
+		return form, form.fromParams(params, context)
 	}
-	return form, form.Initialize(context)
 }
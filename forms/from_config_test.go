@@ -91,3 +91,257 @@ func TestRoundTrip(t *testing.T) {
 		t.Fatalf("expected value 'bar'")
 	}
 }
+
+func TestStrictValidatorConfigs(t *testing.T) {
+
+	config := map[string]interface{}{
+		"fields": []interface{}{
+			map[string]interface{}{
+				"name": "name",
+				"validators": []interface{}{
+					map[string]interface{}{"type": "IsOptional"},
+					map[string]interface{}{"type": "IsString", "config": map[string]interface{}{"minLenght": 3}},
+				},
+			},
+		},
+	}
+
+	nestedConfig := map[string]interface{}{
+		"fields": []interface{}{
+			map[string]interface{}{
+				"name": "tags",
+				"validators": []interface{}{
+					map[string]interface{}{
+						"type": "IsList",
+						"config": map[string]interface{}{
+							"validators": []interface{}{
+								map[string]interface{}{"type": "IsString"},
+								map[string]interface{}{"type": "IsInteger", "config": map[string]interface{}{"min": "foo"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	unknownTypeConfig := map[string]interface{}{
+		"fields": []interface{}{
+			map[string]interface{}{
+				"name":       "name",
+				"validators": []interface{}{map[string]interface{}{"type": "IsStrin"}},
+			},
+		},
+	}
+
+	for _, testCase := range []struct {
+		Config map[string]interface{}
+		Path   string
+	}{
+		{config, "fields[0].validators[1].config.minLenght"},
+		{nestedConfig, "fields[0].validators[0].config.validators[1].config.min"},
+		{unknownTypeConfig, "fields[0].validators[0].type"},
+	} {
+		_, err := FromConfig(testCase.Config, &FormDescriptionContext{Validators: Validators})
+
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+
+		formError, ok := err.(*FormError)
+
+		if !ok || formError.Code() != ConfigErrorCode {
+			t.Fatalf("expected a config error, got %v", err)
+		}
+
+		if _, ok := formError.Errors()[testCase.Path]; !ok {
+			t.Errorf("expected an error for '%s', got %v", testCase.Path, formError.Errors())
+		}
+	}
+
+	if _, err := FromConfig(config, &FormDescriptionContext{Validators: Validators, IgnoreUnknownConfigKeys: true}); err != nil {
+		t.Fatalf("unknown keys should be ignored: %v", err)
+	}
+}
+
+func TestNamedForms(t *testing.T) {
+
+	stringField := func(name string) map[string]interface{} {
+		return map[string]interface{}{
+			"name":       name,
+			"validators": []interface{}{map[string]interface{}{"type": "IsString"}},
+		}
+	}
+
+	formField := func(name, ref string, optional bool) map[string]interface{} {
+		validators := []interface{}{}
+		if optional {
+			validators = append(validators, map[string]interface{}{"type": "IsOptional"})
+		}
+		return map[string]interface{}{
+			"name": name,
+			"validators": append(validators, map[string]interface{}{
+				"type":   "IsStringMap",
+				"config": map[string]interface{}{"form": map[string]interface{}{"$ref": ref}},
+			}),
+		}
+	}
+
+	config := map[string]interface{}{
+		"forms": map[string]interface{}{
+			"Address": map[string]interface{}{
+				"fields": []interface{}{stringField("street"), stringField("city")},
+			},
+			"Node": map[string]interface{}{
+				"fields": []interface{}{
+					stringField("label"),
+					formField("left", "Node", true),
+					formField("right", "Node", true),
+				},
+			},
+		},
+		"fields": []interface{}{
+			formField("billing", "Address", false),
+			formField("shipping", "Address", false),
+			formField("tree", "Node", true),
+		},
+	}
+
+	form, err := FromConfig(config, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if form.Fields[0].Validators[0].(*IsStringMap).Form != form.Fields[1].Validators[0].(*IsStringMap).Form {
+		t.Fatalf("expected named forms to be shared")
+	}
+
+	address := map[string]interface{}{"street": "Main Street", "city": "Berlin"}
+
+	valid := map[string]interface{}{
+		"billing":  address,
+		"shipping": address,
+		"tree": map[string]interface{}{
+			"label": "a",
+			"left": map[string]interface{}{
+				"label": "b",
+				"right": map[string]interface{}{"label": "c"},
+			},
+		},
+	}
+
+	invalid := map[string]interface{}{
+		"billing":  address,
+		"shipping": map[string]interface{}{"street": "Main Street"},
+	}
+
+	invalidTree := map[string]interface{}{
+		"billing":  address,
+		"shipping": address,
+		"tree": map[string]interface{}{
+			"label": "a",
+			"left":  map[string]interface{}{"label": 4},
+		},
+	}
+
+	// the serialized form must not be cyclic and must produce an equivalent form
+	recoveredForm, err := CheckFormRoundTrip(form, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, f := range []*Form{form, recoveredForm} {
+		if _, err := f.Validate(valid); err != nil {
+			t.Fatalf("expected no error but got %v", err)
+		}
+		if _, err := f.Validate(invalid); err == nil {
+			t.Fatalf("expected an error for an incomplete address")
+		}
+		if _, err := f.Validate(invalidTree); err == nil {
+			t.Fatalf("expected an error for an invalid tree node")
+		}
+	}
+
+	// named forms can also be provided via the context
+	context := &FormDescriptionContext{Forms: form.Forms}
+
+	if _, err := FromConfig(map[string]interface{}{
+		"fields": []interface{}{formField("address", "Address", false)},
+	}, context); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = FromConfig(map[string]interface{}{
+		"fields": []interface{}{formField("address", "Adress", false)},
+	}, context)
+
+	if formError, ok := err.(*FormError); !ok {
+		t.Fatalf("expected a form error but got %v", err)
+	} else if _, ok := formError.Errors()["fields[0].validators[0].config.form.$ref"]; !ok {
+		t.Fatalf("expected an error for the unknown form but got %v", formError.Errors())
+	}
+}
+
+func TestRecursiveFormSerialization(t *testing.T) {
+
+	node := &Form{}
+	node.Fields = []Field{
+		{
+			Name:       "label",
+			Validators: []Validator{IsString{}},
+		},
+		{
+			Name:       "children",
+			Validators: []Validator{IsOptional{}, IsList{Validators: []Validator{IsStringMap{Form: node}}}},
+		},
+	}
+
+	// recursive forms that are not named forms cannot be serialized
+	if _, err := DefaultRegistry.SerializeForm(node); err == nil {
+		t.Fatalf("expected an error for a recursive form")
+	}
+
+	if _, err := json.Marshal(node); err == nil {
+		t.Fatalf("expected an error for a recursive form")
+	}
+
+	// named forms are serialized as references
+	form := &Form{
+		Forms: map[string]*Form{"Node": node},
+		Fields: []Field{
+			{
+				Name:       "tree",
+				Validators: []Validator{IsStringMap{Form: node}},
+			},
+		},
+	}
+
+	if _, err := json.Marshal(form); err != nil {
+		t.Fatal(err)
+	}
+
+	recoveredForm, err := CheckFormRoundTrip(form, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tree := map[string]interface{}{
+		"label": "a",
+		"children": []interface{}{
+			map[string]interface{}{"label": "b", "children": []interface{}{map[string]interface{}{"label": "c"}}},
+		},
+	}
+
+	if _, err := recoveredForm.Validate(map[string]interface{}{"tree": tree}); err != nil {
+		t.Fatal(err)
+	}
+
+	tree["children"] = []interface{}{map[string]interface{}{"label": 4}}
+
+	if _, err := recoveredForm.Validate(map[string]interface{}{"tree": tree}); err == nil {
+		t.Fatalf("expected an error for an invalid child")
+	}
+}
@@ -0,0 +1,206 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultGeneratorFunc generates a default value. It receives the values
+// validated so far and the validation context (which may be nil).
+type DefaultGeneratorFunc func(values map[string]interface{}, context map[string]interface{}) (interface{}, error)
+
+// DefaultGeneratorMaker creates a default generator from its arguments,
+// e.g. ["16"] for "randomHex(16)".
+type DefaultGeneratorMaker func(args []string) (DefaultGeneratorFunc, error)
+
+var defaultGeneratorsMutex sync.RWMutex
+
+var defaultGenerators = map[string]DefaultGeneratorMaker{
+	"now":         makeNowGenerator,
+	"uuid4":       makeUUID4Generator,
+	"uuid7":       makeUUID7Generator,
+	"randomHex":   makeRandomHexGenerator,
+	"fromField":   makeFromFieldGenerator,
+	"fromContext": makeFromContextGenerator,
+}
+
+// RegisterDefaultGenerator makes a named default generator available to
+// IsOptional validators (e.g. via {"generator": "name(arg)"}).
+func RegisterDefaultGenerator(name string, maker DefaultGeneratorMaker) {
+	defaultGeneratorsMutex.Lock()
+	defer defaultGeneratorsMutex.Unlock()
+	defaultGenerators[name] = maker
+}
+
+// parses a generator description of the form "name" or "name(arg, ...)"
+func parseGenerator(description string) (string, []string, error) {
+	description = strings.TrimSpace(description)
+	i := strings.Index(description, "(")
+	if i < 0 {
+		return description, []string{}, nil
+	}
+	if !strings.HasSuffix(description, ")") {
+		return "", nil, fmt.Errorf("invalid generator: '%s'", description)
+	}
+	name := strings.TrimSpace(description[:i])
+	args := []string{}
+	if argsStr := strings.TrimSpace(description[i+1 : len(description)-1]); argsStr != "" {
+		for _, arg := range strings.Split(argsStr, ",") {
+			args = append(args, strings.TrimSpace(arg))
+		}
+	}
+	return name, args, nil
+}
+
+// MakeDefaultGenerator creates the default generator with the given
+// description, e.g. "uuid4" or "fromField(name)".
+func MakeDefaultGenerator(description string) (DefaultGeneratorFunc, error) {
+	name, args, err := parseGenerator(description)
+	if err != nil {
+		return nil, err
+	}
+	defaultGeneratorsMutex.RLock()
+	maker, ok := defaultGenerators[name]
+	defaultGeneratorsMutex.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown generator: '%s'", name)
+	}
+	return maker(args)
+}
+
+type IsValidDefaultGenerator struct {
+}
+
+func (i IsValidDefaultGenerator) Validate(value interface{}, values map[string]interface{}) (interface{}, error) {
+	strValue, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected a string")
+	}
+	if _, err := MakeDefaultGenerator(strValue); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+func checkGeneratorArgs(args []string, n int) error {
+	if len(args) != n {
+		return fmt.Errorf("expected %d argument(s) but got %d", n, len(args))
+	}
+	return nil
+}
+
+// returns the current time in RFC 3339 format (in UTC)
+func makeNowGenerator(args []string) (DefaultGeneratorFunc, error) {
+	if err := checkGeneratorArgs(args, 0); err != nil {
+		return nil, err
+	}
+	return func(values map[string]interface{}, context map[string]interface{}) (interface{}, error) {
+		return time.Now().UTC().Format(time.RFC3339), nil
+	}, nil
+}
+
+// returns a random (version 4) UUID in canonical form
+func makeUUID4Generator(args []string) (DefaultGeneratorFunc, error) {
+	if err := checkGeneratorArgs(args, 0); err != nil {
+		return nil, err
+	}
+	return func(values map[string]interface{}, context map[string]interface{}) (interface{}, error) {
+		var uuid UUID
+		if _, err := rand.Read(uuid[:]); err != nil {
+			return nil, err
+		}
+		uuid[6] = (uuid[6] & 0x0f) | 0x40
+		uuid[8] = (uuid[8] & 0x3f) | 0x80
+		return uuid.String(), nil
+	}, nil
+}
+
+// returns a time-ordered (version 7) UUID in canonical form
+func makeUUID7Generator(args []string) (DefaultGeneratorFunc, error) {
+	if err := checkGeneratorArgs(args, 0); err != nil {
+		return nil, err
+	}
+	return func(values map[string]interface{}, context map[string]interface{}) (interface{}, error) {
+		var uuid UUID
+		if _, err := rand.Read(uuid[6:]); err != nil {
+			return nil, err
+		}
+		var timestamp [8]byte
+		// the first 48 bits contain the Unix timestamp in milliseconds
+		binary.BigEndian.PutUint64(timestamp[:], uint64(time.Now().UnixMilli()))
+		copy(uuid[:6], timestamp[2:])
+		uuid[6] = (uuid[6] & 0x0f) | 0x70
+		uuid[8] = (uuid[8] & 0x3f) | 0x80
+		return uuid.String(), nil
+	}, nil
+}
+
+// returns n random bytes (16 by default) as a hex string
+func makeRandomHexGenerator(args []string) (DefaultGeneratorFunc, error) {
+	n := 16
+	if len(args) > 1 {
+		return nil, fmt.Errorf("expected at most 1 argument but got %d", len(args))
+	} else if len(args) == 1 {
+		var err error
+		if n, err = strconv.Atoi(args[0]); err != nil || n < 1 || n > 1024 {
+			return nil, fmt.Errorf("invalid number of bytes: '%s'", args[0])
+		}
+	}
+	return func(values map[string]interface{}, context map[string]interface{}) (interface{}, error) {
+		bytes := make([]byte, n)
+		if _, err := rand.Read(bytes); err != nil {
+			return nil, err
+		}
+		return hex.EncodeToString(bytes), nil
+	}, nil
+}
+
+// returns the (validated) value of another field, which therefore has to
+// be declared before the field with the default
+func makeFromFieldGenerator(args []string) (DefaultGeneratorFunc, error) {
+	if err := checkGeneratorArgs(args, 1); err != nil {
+		return nil, err
+	}
+	return func(values map[string]interface{}, context map[string]interface{}) (interface{}, error) {
+		return values[args[0]], nil
+	}, nil
+}
+
+// returns a value from the validation context, nested values can be
+// addressed with dotted keys (e.g. "user.id")
+func makeFromContextGenerator(args []string) (DefaultGeneratorFunc, error) {
+	if err := checkGeneratorArgs(args, 1); err != nil {
+		return nil, err
+	}
+	return func(values map[string]interface{}, context map[string]interface{}) (interface{}, error) {
+		var value interface{} = context
+		for _, key := range strings.Split(args[0], ".") {
+			if m, ok := value.(map[string]interface{}); ok {
+				value = m[key]
+			} else {
+				return nil, nil
+			}
+		}
+		return value, nil
+	}, nil
+}
@@ -0,0 +1,124 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"testing"
+)
+
+func TestDefaultGenerators(t *testing.T) {
+
+	field := func(name, generator string, validators ...interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"name": name,
+			"validators": append([]interface{}{
+				map[string]interface{}{"type": "IsOptional", "config": map[string]interface{}{"generator": generator}},
+			}, validators...),
+		}
+	}
+
+	config := map[string]interface{}{
+		"fields": []interface{}{
+			field("id", "uuid4", map[string]interface{}{"type": "IsUUID", "config": map[string]interface{}{"versions": []interface{}{4}}}),
+			field("eventID", "uuid7", map[string]interface{}{"type": "IsUUID", "config": map[string]interface{}{"versions": []interface{}{7}}}),
+			field("createdAt", "now", map[string]interface{}{"type": "IsTime"}),
+			field("token", "randomHex(8)", map[string]interface{}{"type": "IsHex"}),
+			field("name", "fromContext(user.name)", map[string]interface{}{"type": "IsString"}),
+			field("displayName", "fromField(name)", map[string]interface{}{"type": "IsString"}),
+		},
+	}
+
+	form, err := FromConfig(config, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recoveredForm, err := CheckFormRoundTrip(form, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	context := map[string]interface{}{"user": map[string]interface{}{"name": "max"}}
+
+	for _, f := range []*Form{form, recoveredForm} {
+		values, err := f.ValidateWithContext(map[string]interface{}{}, context)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if values["name"] != "max" || values["displayName"] != "max" {
+			t.Fatalf("unexpected names: %v", values)
+		}
+		if token, ok := values["token"].(string); !ok || len(token) != 16 {
+			t.Fatalf("unexpected token: %v", values["token"])
+		}
+		for _, key := range []string{"id", "eventID", "createdAt"} {
+			if values[key] == nil {
+				t.Fatalf("expected a value for '%s'", key)
+			}
+		}
+		// given values take precedence over generated ones
+		if values, err := f.ValidateWithContext(map[string]interface{}{"displayName": "Maxi"}, context); err != nil {
+			t.Fatal(err)
+		} else if values["displayName"] != "Maxi" {
+			t.Fatalf("expected the given value")
+		}
+	}
+
+	for _, generator := range []string{"uuid5", "randomHex(0)", "randomHex(x)", "fromField()", "now(1)", "now("} {
+		if _, err := FromConfig(map[string]interface{}{"fields": []interface{}{field("foo", generator)}}, nil); err == nil {
+			t.Fatalf("expected an error for generator '%s'", generator)
+		}
+	}
+
+	RegisterDefaultGenerator("constant", func(args []string) (DefaultGeneratorFunc, error) {
+		return func(values map[string]interface{}, context map[string]interface{}) (interface{}, error) {
+			return "constant", nil
+		}, nil
+	})
+
+	makerCalls := 0
+
+	RegisterDefaultGenerator("counted", func(args []string) (DefaultGeneratorFunc, error) {
+		makerCalls++
+		return func(values map[string]interface{}, context map[string]interface{}) (interface{}, error) {
+			return makerCalls, nil
+		}, nil
+	})
+
+	validator, err := MakeIsOptionalValidator(map[string]interface{}{"generator": "counted"}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := makerCalls
+
+	// the generator is created when the validator is created
+	for i := 0; i < 3; i++ {
+		if value, err := validator.Validate(nil, nil); err != nil {
+			t.Fatal(err)
+		} else if value != calls {
+			t.Fatalf("expected the generator to be created only once")
+		}
+	}
+
+	if values, err := (&Form{Fields: []Field{{Name: "foo", Validators: []Validator{IsOptional{Generator: "constant"}}}}}).Validate(map[string]interface{}{}); err != nil {
+		t.Fatal(err)
+	} else if values["foo"] != "constant" {
+		t.Fatalf("expected a value from the custom generator")
+	}
+}
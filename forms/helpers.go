@@ -17,8 +17,10 @@ package forms
 // https://gist.github.com/stoewer/fbe273b711e6a06315d19552dd4d33e6
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
+	"unicode"
 )
 
 var matchFirstCap = regexp.MustCompile("(.)([A-Z][a-z]+)")
@@ -29,3 +31,147 @@ func ToSnakeCase(str string) string {
 	snake = matchAllCap.ReplaceAllString(snake, "${1}_${2}")
 	return strings.ToLower(snake)
 }
+
+// removes whitespace and separator characters from an identifier (e.g. an
+// IBAN or a VAT ID) and converts it to upper case.
+func normalizeIdentifier(str string, separators string) string {
+	return strings.ToUpper(strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || strings.ContainsRune(separators, r) {
+			return -1
+		}
+		return r
+	}, str))
+}
+
+// replaces all but the first keepStart and last keepEnd characters of a
+// string with asterisks.
+func maskString(str string, keepStart, keepEnd int) string {
+	if keepStart+keepEnd >= len(str) {
+		return str
+	}
+	return str[:keepStart] + strings.Repeat("*", len(str)-keepStart-keepEnd) + str[len(str)-keepEnd:]
+}
+
+type numberLocale struct {
+	Decimal string
+	Groups  []string
+}
+
+// decimal and grouping separators used when converting numeric strings,
+// keyed by language tag (a tag like "de-AT" falls back to "de")
+var numberLocales = map[string]numberLocale{
+	"en":    {".", []string{","}},
+	"de":    {",", []string{"."}},
+	"de-CH": {".", []string{"'", "\u2019"}},
+	"es":    {",", []string{"."}},
+	"it":    {",", []string{"."}},
+	"nl":    {",", []string{"."}},
+	"pt":    {",", []string{"."}},
+	"da":    {",", []string{"."}},
+	"tr":    {",", []string{"."}},
+	"fr":    {",", []string{" ", "\u00a0", "\u202f"}},
+	"cs":    {",", []string{" ", "\u00a0"}},
+	"fi":    {",", []string{" ", "\u00a0"}},
+	"nb":    {",", []string{" ", "\u00a0"}},
+	"pl":    {",", []string{" ", "\u00a0"}},
+	"ru":    {",", []string{" ", "\u00a0"}},
+	"sv":    {",", []string{" ", "\u00a0"}},
+}
+
+func lookupNumberLocale(locale string) (numberLocale, bool) {
+	locale = strings.Replace(locale, "_", "-", -1)
+	if l, ok := numberLocales[locale]; ok {
+		return l, true
+	}
+	if i := strings.Index(locale, "-"); i >= 0 {
+		locale = locale[:i]
+	}
+	l, ok := numberLocales[strings.ToLower(locale)]
+	return l, ok
+}
+
+// converts a number formatted according to the given locale (e.g. "1.234,5"
+// for "de") to the format expected by strconv (e.g. "1234.5"). Grouping
+// separators are optional but, if present, must separate groups of three
+// digits.
+func normalizeNumber(str, locale string) (string, error) {
+
+	str = strings.TrimSpace(str)
+
+	if locale == "" {
+		return str, nil
+	}
+
+	l, ok := lookupNumberLocale(locale)
+
+	if !ok {
+		return "", fmt.Errorf("unsupported locale: '%s'", locale)
+	}
+
+	sign := ""
+
+	if strings.HasPrefix(str, "-") || strings.HasPrefix(str, "+") {
+		sign, str = str[:1], str[1:]
+	}
+
+	parts := strings.Split(str, l.Decimal)
+
+	if len(parts) > 2 {
+		return "", fmt.Errorf("invalid number: '%s'", str)
+	}
+
+	integerPart := parts[0]
+
+	for _, group := range l.Groups[1:] {
+		integerPart = strings.Replace(integerPart, group, l.Groups[0], -1)
+	}
+
+	if groups := strings.Split(integerPart, l.Groups[0]); len(groups) > 1 {
+		for i, group := range groups {
+			if (i == 0 && (len(group) < 1 || len(group) > 3)) || (i > 0 && len(group) != 3) {
+				return "", fmt.Errorf("invalid digit grouping: '%s'", str)
+			}
+		}
+		integerPart = strings.Join(groups, "")
+	}
+
+	if len(parts) == 2 {
+		return sign + integerPart + "." + parts[1], nil
+	}
+
+	return sign + integerPart, nil
+}
+
+// returns the edit distance between the two strings, counting insertions,
+// deletions, substitutions and transpositions of adjacent characters
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	rows := make([][]int, len(ra)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(rb)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			distance := rows[i-1][j-1] + cost
+			if rows[i-1][j]+1 < distance {
+				distance = rows[i-1][j] + 1
+			}
+			if rows[i][j-1]+1 < distance {
+				distance = rows[i][j-1] + 1
+			}
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] && rows[i-2][j-2]+1 < distance {
+				distance = rows[i-2][j-2] + 1
+			}
+			rows[i][j] = distance
+		}
+	}
+	return rows[len(ra)][len(rb)]
+}
@@ -0,0 +1,426 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// JSONPatchOperationForm validates JSON Patch operations (RFC 6902)
+var JSONPatchOperationForm = Form{
+	Fields: []Field{
+		{
+			Name: "op",
+			Validators: []Validator{
+				IsIn{
+					Choices: []interface{}{"add", "remove", "replace", "move", "copy", "test"},
+				},
+			},
+		},
+		{
+			Name: "path",
+			Validators: []Validator{
+				IsString{},
+			},
+		},
+		{
+			Name: "from",
+			Validators: []Validator{
+				IsOptional{},
+				IsString{},
+			},
+		},
+		{
+			Name: "value",
+			Validators: []Validator{
+				CanBeAnything{},
+			},
+		},
+	},
+}
+
+type JSONPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	From  string      `json:"from,omitempty"`
+	Value interface{} `json:"value"`
+}
+
+// ApplyMergePatch applies a JSON Merge Patch (RFC 7396) to the current
+// values and validates the result with the form. It returns the validated
+// values and the JSON pointers of the values that were changed by the patch.
+// The current values are not modified.
+func (f *Form) ApplyMergePatch(current, patch map[string]interface{}) (map[string]interface{}, []string, error) {
+	document := mergePatch(deepCopy(current), patch)
+	return f.validatePatched(current, document.(map[string]interface{}))
+}
+
+// ApplyJSONPatch applies the given JSON Patch (RFC 6902) operations to the
+// current values and validates the result with the form. It returns the
+// validated values and the JSON pointers of the values that were changed by
+// the patch. The current values are not modified.
+func (f *Form) ApplyJSONPatch(current map[string]interface{}, operations []interface{}) (map[string]interface{}, []string, error) {
+
+	var document interface{} = deepCopy(current)
+
+	for i, operation := range operations {
+
+		config, ok := operation.(map[string]interface{})
+
+		if !ok {
+			return nil, nil, fmt.Errorf("operation %d: not a string map", i)
+		}
+
+		jsonPatchOperation := &JSONPatchOperation{}
+
+		if params, err := JSONPatchOperationForm.Validate(config); err != nil {
+			return nil, nil, fmt.Errorf("operation %d: %v", i, err)
+		} else if err := JSONPatchOperationForm.Coerce(jsonPatchOperation, params); err != nil {
+			return nil, nil, fmt.Errorf("operation %d: %v", i, err)
+		}
+
+		// the value may be null, so we check the raw operation
+		value, hasValue := config["value"]
+		jsonPatchOperation.Value = value
+
+		switch jsonPatchOperation.Op {
+		case "add", "replace", "test":
+			if !hasValue {
+				return nil, nil, fmt.Errorf("operation %d: value is missing", i)
+			}
+		case "move", "copy":
+			if _, ok := config["from"]; !ok {
+				return nil, nil, fmt.Errorf("operation %d: from is missing", i)
+			}
+		}
+
+		var err error
+
+		if document, err = jsonPatchOperation.apply(document); err != nil {
+			return nil, nil, fmt.Errorf("operation %d (%s '%s'): %v", i, jsonPatchOperation.Op, jsonPatchOperation.Path, err)
+		}
+	}
+
+	patchedDocument, ok := document.(map[string]interface{})
+
+	if !ok {
+		return nil, nil, fmt.Errorf("patched document is not a string map")
+	}
+
+	return f.validatePatched(current, patchedDocument)
+}
+
+func (f *Form) validatePatched(current, document map[string]interface{}) (map[string]interface{}, []string, error) {
+	changed := []string{}
+	changedPaths(current, document, "", &changed)
+	sort.Strings(changed)
+	if values, err := f.Validate(document); err != nil {
+		return nil, nil, err
+	} else {
+		return values, changed, nil
+	}
+}
+
+// applies the operation to the document, which may be modified in place
+func (o *JSONPatchOperation) apply(document interface{}) (interface{}, error) {
+
+	tokens, err := parseJSONPointer(o.Path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch o.Op {
+	case "add":
+		return addValue(document, tokens, deepCopy(o.Value))
+	case "remove":
+		document, _, err = removeValue(document, tokens)
+		return document, err
+	case "replace":
+		if len(tokens) == 0 {
+			// replacing the root replaces the whole document
+			return deepCopy(o.Value), nil
+		}
+		if document, _, err = removeValue(document, tokens); err != nil {
+			return nil, err
+		}
+		return addValue(document, tokens, deepCopy(o.Value))
+	case "test":
+		if value, err := getValue(document, tokens); err != nil {
+			return nil, err
+		} else if !jsonEqual(value, o.Value) {
+			return nil, fmt.Errorf("test failed")
+		}
+		return document, nil
+	case "move", "copy":
+		fromTokens, err := parseJSONPointer(o.From)
+		if err != nil {
+			return nil, err
+		}
+		var value interface{}
+		if o.Op == "move" {
+			if strings.HasPrefix(o.Path, o.From+"/") {
+				return nil, fmt.Errorf("cannot move a value into itself")
+			}
+			document, value, err = removeValue(document, fromTokens)
+		} else {
+			value, err = getValue(document, fromTokens)
+			value = deepCopy(value)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return addValue(document, tokens, value)
+	}
+
+	return nil, fmt.Errorf("unknown operation")
+}
+
+// parses a JSON pointer (RFC 6901)
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if pointer[0] != '/' {
+		return nil, fmt.Errorf("invalid JSON pointer: '%s'", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+	}
+	return tokens, nil
+}
+
+func formatJSONPointer(prefix, token string) string {
+	return prefix + "/" + strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// returns the list index for the given token, allowing len(list) if end is true
+func listIndex(token string, length int, end bool) (int, error) {
+	if end && token == "-" {
+		return length, nil
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index < 0 || (token != "0" && token[0] == '0') {
+		return 0, fmt.Errorf("invalid list index: '%s'", token)
+	}
+	if index > length || (index == length && !end) {
+		return 0, fmt.Errorf("list index out of range: %d", index)
+	}
+	return index, nil
+}
+
+// updates the value at the given path and returns the updated document
+func updateValue(document interface{}, tokens []string, update func(interface{}) (interface{}, error)) (interface{}, error) {
+	if len(tokens) == 0 {
+		return update(document)
+	}
+	switch container := document.(type) {
+	case map[string]interface{}:
+		child, ok := container[tokens[0]]
+		if !ok {
+			return nil, fmt.Errorf("path does not exist")
+		}
+		if newChild, err := updateValue(child, tokens[1:], update); err != nil {
+			return nil, err
+		} else {
+			container[tokens[0]] = newChild
+		}
+		return container, nil
+	case []interface{}:
+		index, err := listIndex(tokens[0], len(container), false)
+		if err != nil {
+			return nil, err
+		}
+		if newChild, err := updateValue(container[index], tokens[1:], update); err != nil {
+			return nil, err
+		} else {
+			container[index] = newChild
+		}
+		return container, nil
+	}
+	return nil, fmt.Errorf("path does not exist")
+}
+
+func getValue(document interface{}, tokens []string) (interface{}, error) {
+	var value interface{}
+	_, err := updateValue(document, tokens, func(v interface{}) (interface{}, error) {
+		value = v
+		return v, nil
+	})
+	return value, err
+}
+
+func addValue(document interface{}, tokens []string, value interface{}) (interface{}, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+	last := tokens[len(tokens)-1]
+	return updateValue(document, tokens[:len(tokens)-1], func(parent interface{}) (interface{}, error) {
+		switch container := parent.(type) {
+		case map[string]interface{}:
+			container[last] = value
+			return container, nil
+		case []interface{}:
+			index, err := listIndex(last, len(container), true)
+			if err != nil {
+				return nil, err
+			}
+			container = append(container, nil)
+			copy(container[index+1:], container[index:])
+			container[index] = value
+			return container, nil
+		}
+		return nil, fmt.Errorf("parent is not a map or list")
+	})
+}
+
+func removeValue(document interface{}, tokens []string) (interface{}, interface{}, error) {
+	if len(tokens) == 0 {
+		return nil, nil, fmt.Errorf("cannot remove the document")
+	}
+	var value interface{}
+	last := tokens[len(tokens)-1]
+	document, err := updateValue(document, tokens[:len(tokens)-1], func(parent interface{}) (interface{}, error) {
+		switch container := parent.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if value, ok = container[last]; !ok {
+				return nil, fmt.Errorf("path does not exist")
+			}
+			delete(container, last)
+			return container, nil
+		case []interface{}:
+			index, err := listIndex(last, len(container), false)
+			if err != nil {
+				return nil, err
+			}
+			value = container[index]
+			return append(container[:index], container[index+1:]...), nil
+		}
+		return nil, fmt.Errorf("path does not exist")
+	})
+	return document, value, err
+}
+
+// applies a JSON merge patch, modifying the target in place
+func mergePatch(target, patch interface{}) interface{} {
+	patchMap, ok := patch.(map[string]interface{})
+	if !ok {
+		return deepCopy(patch)
+	}
+	targetMap, ok := target.(map[string]interface{})
+	if !ok {
+		targetMap = map[string]interface{}{}
+	}
+	for key, value := range patchMap {
+		if value == nil {
+			delete(targetMap, key)
+		} else {
+			targetMap[key] = mergePatch(targetMap[key], value)
+		}
+	}
+	return targetMap
+}
+
+// copies nested maps and lists so that they can be modified in place
+func deepCopy(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, entry := range v {
+			m[key] = deepCopy(entry)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, entry := range v {
+			l[i] = deepCopy(entry)
+		}
+		return l
+	}
+	if value != nil {
+		// we convert other lists (e.g. []string) so they can be patched
+		if v := reflect.ValueOf(value); v.Kind() == reflect.Slice {
+			l := make([]interface{}, v.Len())
+			for i := range l {
+				l[i] = deepCopy(v.Index(i).Interface())
+			}
+			return l
+		}
+	}
+	return value
+}
+
+// compares two values, treating numbers of different types as equal
+func jsonEqual(a, b interface{}) bool {
+	if af, ok := toFloat(a); ok {
+		bf, ok := toFloat(b)
+		return ok && af == bf
+	}
+	return reflect.DeepEqual(deepCopy(a), deepCopy(b))
+}
+
+// converts numbers of any type (including json.Number) to floats
+func toFloat(value interface{}) (float64, bool) {
+	if number, ok := value.(json.Number); ok {
+		f, err := number.Float64()
+		return f, err == nil
+	}
+	// IsFloat does not convert strings unless asked to
+	if f, err := (IsFloat{}).Validate(value, nil); err == nil {
+		return f.(float64), true
+	}
+	return 0, false
+}
+
+// adds the JSON pointers of all values that differ between a and b. Lists
+// are compared element-wise if their length did not change, otherwise they
+// are reported as a whole.
+func changedPaths(a, b interface{}, prefix string, changed *[]string) {
+	aMap, aIsMap := a.(map[string]interface{})
+	bMap, bIsMap := b.(map[string]interface{})
+	if aIsMap && bIsMap {
+		for key, value := range aMap {
+			if _, ok := bMap[key]; !ok {
+				*changed = append(*changed, formatJSONPointer(prefix, key))
+			} else {
+				changedPaths(value, bMap[key], formatJSONPointer(prefix, key), changed)
+			}
+		}
+		for key := range bMap {
+			if _, ok := aMap[key]; !ok {
+				*changed = append(*changed, formatJSONPointer(prefix, key))
+			}
+		}
+		return
+	}
+	aList, aIsList := deepCopy(a).([]interface{})
+	bList, bIsList := b.([]interface{})
+	if aIsList && bIsList && len(aList) == len(bList) {
+		for i := range aList {
+			changedPaths(aList[i], bList[i], formatJSONPointer(prefix, strconv.Itoa(i)), changed)
+		}
+		return
+	}
+	if !jsonEqual(a, b) {
+		*changed = append(*changed, prefix)
+	}
+}
@@ -0,0 +1,156 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+var patchForm = &Form{
+	Fields: []Field{
+		{
+			Name:       "name",
+			Validators: []Validator{IsString{}},
+		},
+		{
+			Name:       "nickname",
+			Validators: []Validator{IsOptional{}, IsString{}},
+		},
+		{
+			Name:       "tags",
+			Validators: []Validator{IsOptional{}, IsStringList{}},
+		},
+		{
+			Name:       "address",
+			Validators: []Validator{IsOptional{}, IsStringMap{}},
+		},
+	},
+}
+
+func patchDocument() map[string]interface{} {
+	return map[string]interface{}{
+		"name":     "Max",
+		"nickname": "maxi",
+		"tags":     []interface{}{"a", "b"},
+		"address":  map[string]interface{}{"city": "Berlin", "zip": "10115"},
+	}
+}
+
+func TestApplyMergePatch(t *testing.T) {
+
+	current := patchDocument()
+
+	values, changed, err := patchForm.ApplyMergePatch(current, map[string]interface{}{
+		"nickname": nil,
+		"address":  map[string]interface{}{"zip": nil, "street": "Main Street"},
+		"name":     "Max",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := values["nickname"]; ok {
+		t.Fatalf("expected the nickname to be removed")
+	}
+
+	if !reflect.DeepEqual(values["address"], map[string]interface{}{"city": "Berlin", "street": "Main Street"}) {
+		t.Fatalf("unexpected address: %v", values["address"])
+	}
+
+	if !reflect.DeepEqual(changed, []string{"/address/street", "/address/zip", "/nickname"}) {
+		t.Fatalf("unexpected changed paths: %v", changed)
+	}
+
+	if !reflect.DeepEqual(current, patchDocument()) {
+		t.Fatalf("the current values were modified")
+	}
+
+	if _, _, err := patchForm.ApplyMergePatch(current, map[string]interface{}{"name": nil}); err == nil {
+		t.Fatalf("expected an error when removing a required field")
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+
+	current := patchDocument()
+
+	values, changed, err := patchForm.ApplyJSONPatch(current, []interface{}{
+		map[string]interface{}{"op": "test", "path": "/name", "value": "Max"},
+		map[string]interface{}{"op": "add", "path": "/tags/1", "value": "c"},
+		map[string]interface{}{"op": "add", "path": "/tags/-", "value": "d"},
+		map[string]interface{}{"op": "remove", "path": "/tags/0"},
+		map[string]interface{}{"op": "replace", "path": "/address/city", "value": "Hamburg"},
+		map[string]interface{}{"op": "move", "path": "/address/code", "from": "/address/zip"},
+		map[string]interface{}{"op": "copy", "path": "/nickname", "from": "/name"},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(values["tags"], []string{"c", "b", "d"}) {
+		t.Fatalf("unexpected tags: %v", values["tags"])
+	}
+
+	if !reflect.DeepEqual(values["address"], map[string]interface{}{"city": "Hamburg", "code": "10115"}) {
+		t.Fatalf("unexpected address: %v", values["address"])
+	}
+
+	if !reflect.DeepEqual(changed, []string{"/address/city", "/address/code", "/address/zip", "/nickname", "/tags"}) {
+		t.Fatalf("unexpected changed paths: %v", changed)
+	}
+
+	if !reflect.DeepEqual(current, patchDocument()) {
+		t.Fatalf("the current values were modified")
+	}
+
+	// replacing the root replaces the whole document
+	if values, _, err := patchForm.ApplyJSONPatch(current, []interface{}{
+		map[string]interface{}{"op": "replace", "path": "", "value": map[string]interface{}{"name": "Maria"}},
+	}); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(values, map[string]interface{}{"name": "Maria"}) {
+		t.Fatalf("unexpected values: %v", values)
+	}
+
+	// numbers of different types are compared by value
+	for _, value := range []interface{}{int32(3), float32(3), json.Number("3"), 3.0} {
+		document := patchDocument()
+		document["address"] = map[string]interface{}{"floor": 3}
+		if _, _, err := patchForm.ApplyJSONPatch(document, []interface{}{
+			map[string]interface{}{"op": "test", "path": "/address/floor", "value": value},
+		}); err != nil {
+			t.Fatalf("%T: %v", value, err)
+		}
+	}
+
+	for i, operations := range [][]interface{}{
+		{map[string]interface{}{"op": "test", "path": "/name", "value": "Maria"}},
+		{map[string]interface{}{"op": "remove", "path": "/missing"}},
+		{map[string]interface{}{"op": "replace", "path": "/tags/5", "value": "x"}},
+		{map[string]interface{}{"op": "add", "path": "/name"}},
+		{map[string]interface{}{"op": "move", "path": "/address/city/x", "from": "/address"}},
+		{map[string]interface{}{"op": "invalid", "path": "/name"}},
+		{map[string]interface{}{"op": "remove", "path": "/name"}},
+		{map[string]interface{}{"op": "replace", "path": "/name", "value": nil}},
+	} {
+		if _, _, err := patchForm.ApplyJSONPatch(current, operations); err == nil {
+			t.Fatalf("test %d: expected an error", i)
+		}
+	}
+}
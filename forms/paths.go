@@ -0,0 +1,221 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// A field path addresses values in nested maps and lists, e.g.
+// "address.city", "items[*].price" or "items[0].tags[*]". Keys are
+// separated by dots, list indexes are given in brackets and "[*]" matches
+// all entries of a list. Path fields should be declared after fields that
+// validate their parent values, as these replace the parent values. Keys
+// below the root of a path that are not addressed by any path are rejected
+// by forms that reject unknown keys and removed from the values otherwise.
+type pathSegment struct {
+	Key      string
+	Index    int
+	IsIndex  bool
+	Wildcard bool
+}
+
+// returns true if the given field name might be a path
+func isFieldPath(name string) bool {
+	return strings.ContainsAny(name, ".[")
+}
+
+// parses the given field path, returns false if it is malformed
+func parseFieldPath(name string) ([]pathSegment, bool) {
+	segments := []pathSegment{}
+	for _, part := range strings.Split(name, ".") {
+		key := part
+		indexes := ""
+		if i := strings.Index(part, "["); i >= 0 {
+			key = part[:i]
+			indexes = part[i:]
+		}
+		if key == "" {
+			return nil, false
+		}
+		segments = append(segments, pathSegment{Key: key})
+		for indexes != "" {
+			end := strings.Index(indexes, "]")
+			if indexes[0] != '[' || end < 2 {
+				return nil, false
+			}
+			if index := indexes[1:end]; index == "*" {
+				segments = append(segments, pathSegment{Wildcard: true})
+			} else if n, err := strconv.Atoi(index); err != nil || n < 0 {
+				return nil, false
+			} else {
+				segments = append(segments, pathSegment{Index: n, IsIndex: true})
+			}
+			indexes = indexes[end+1:]
+		}
+	}
+	return segments, true
+}
+
+// the root key of a field name, which for paths is the first key
+func fieldRoot(name string) string {
+	if isFieldPath(name) {
+		if segments, ok := parseFieldPath(name); ok {
+			return segments[0].Key
+		}
+	}
+	return name
+}
+
+// formats a concrete path (consisting of string keys and int indexes)
+func formatFieldPath(path []interface{}) string {
+	var builder strings.Builder
+	for i, key := range path {
+		switch k := key.(type) {
+		case int:
+			builder.WriteString("[" + strconv.Itoa(k) + "]")
+		case string:
+			if i > 0 {
+				builder.WriteString(".")
+			}
+			builder.WriteString(k)
+		}
+	}
+	return builder.String()
+}
+
+type pathValue struct {
+	Path  []interface{}
+	Value interface{}
+	// Error is set if the value at Path is not a map or list as required
+	Error error
+}
+
+// returns the values addressed by the given path segments together with
+// their concrete paths. Missing values are returned as nil, wildcards over
+// missing lists match no values. If a value that is not missing is not a
+// map or list as required, an error for its path is returned instead.
+func expandFieldPath(value interface{}, segments []pathSegment, path []interface{}) []pathValue {
+
+	if len(segments) == 0 {
+		return []pathValue{{Path: path, Value: value}}
+	}
+
+	segment := segments[0]
+
+	extend := func(key interface{}) []interface{} {
+		newPath := make([]interface{}, len(path), len(path)+1)
+		copy(newPath, path)
+		return append(newPath, key)
+	}
+
+	var list reflect.Value
+
+	if segment.IsIndex || segment.Wildcard {
+		if value != nil {
+			if v := reflect.ValueOf(value); v.Kind() == reflect.Slice {
+				list = v
+			} else {
+				return []pathValue{{Path: path, Error: fmt.Errorf("expected a list")}}
+			}
+		}
+	} else if _, ok := value.(map[string]interface{}); !ok && value != nil {
+		return []pathValue{{Path: path, Error: fmt.Errorf("expected a map")}}
+	}
+
+	switch {
+	case segment.Wildcard:
+		values := []pathValue{}
+		if list.IsValid() {
+			for i := 0; i < list.Len(); i++ {
+				values = append(values, expandFieldPath(list.Index(i).Interface(), segments[1:], extend(i))...)
+			}
+		}
+		return values
+	case segment.IsIndex:
+		var entry interface{}
+		if list.IsValid() && segment.Index < list.Len() {
+			entry = list.Index(segment.Index).Interface()
+		}
+		return expandFieldPath(entry, segments[1:], extend(segment.Index))
+	default:
+		var entry interface{}
+		if m, ok := value.(map[string]interface{}); ok {
+			entry = m[segment.Key]
+		}
+		return expandFieldPath(entry, segments[1:], extend(segment.Key))
+	}
+}
+
+// pathWriter writes values to concrete paths in nested maps and lists.
+// Containers are copied before they are modified (so that input values are
+// never changed) but only once per writer.
+type pathWriter struct {
+	owned map[uintptr]bool
+}
+
+func makePathWriter() *pathWriter {
+	return &pathWriter{owned: map[uintptr]bool{}}
+}
+
+func (w *pathWriter) set(values map[string]interface{}, path []interface{}, value interface{}) {
+	key := path[0].(string)
+	values[key] = w.setIn(values[key], path[1:], value)
+}
+
+func (w *pathWriter) setIn(container interface{}, path []interface{}, value interface{}) interface{} {
+
+	if len(path) == 0 {
+		return value
+	}
+
+	switch key := path[0].(type) {
+	case int:
+		list, ok := container.([]interface{})
+		if !ok || !w.owned[reflect.ValueOf(list).Pointer()] {
+			list = []interface{}{}
+			if container != nil {
+				if v := reflect.ValueOf(container); v.Kind() == reflect.Slice {
+					for i := 0; i < v.Len(); i++ {
+						list = append(list, v.Index(i).Interface())
+					}
+				}
+			}
+		}
+		for len(list) <= key {
+			list = append(list, nil)
+		}
+		list[key] = w.setIn(list[key], path[1:], value)
+		w.owned[reflect.ValueOf(list).Pointer()] = true
+		return list
+	case string:
+		m, ok := container.(map[string]interface{})
+		if !ok || !w.owned[reflect.ValueOf(m).Pointer()] {
+			newMap := map[string]interface{}{}
+			for k, v := range m {
+				newMap[k] = v
+			}
+			m = newMap
+			w.owned[reflect.ValueOf(m).Pointer()] = true
+		}
+		m[key] = w.setIn(m[key], path[1:], value)
+		return m
+	}
+
+	return container
+}
@@ -0,0 +1,175 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"testing"
+)
+
+func TestFieldPaths(t *testing.T) {
+
+	form := &Form{
+		Strict: true,
+		Fields: []Field{
+			{
+				Name:       "address.city",
+				Validators: []Validator{IsString{MinLength: 2}},
+			},
+			{
+				Name:       "address.country",
+				Validators: []Validator{IsOptional{Default: "DE"}, IsString{}},
+			},
+			{
+				Name:       "items[*].price",
+				Validators: []Validator{IsFloat{}},
+			},
+			{
+				Name:       "items[0].name",
+				Validators: []Validator{IsOptional{}, IsString{}},
+			},
+		},
+	}
+
+	items := []interface{}{
+		map[string]interface{}{"price": 1.5, "name": "foo"},
+		map[string]interface{}{"price": 2.0},
+	}
+
+	input := map[string]interface{}{
+		"address": map[string]interface{}{"city": "Berlin"},
+		"items":   items,
+	}
+
+	values, err := form.Validate(input)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address, ok := values["address"].(map[string]interface{})
+
+	if !ok || address["city"] != "Berlin" || address["country"] != "DE" {
+		t.Fatalf("unexpected address: %v", values["address"])
+	}
+
+	validatedItems, ok := values["items"].([]interface{})
+
+	if !ok || len(validatedItems) != 2 {
+		t.Fatalf("unexpected items: %v", values["items"])
+	}
+
+	if first := validatedItems[0].(map[string]interface{}); first["price"] != 1.5 || first["name"] != "foo" {
+		t.Fatalf("unexpected item: %v", first)
+	}
+
+	// the input must not be modified
+	if _, ok := input["address"].(map[string]interface{})["country"]; ok {
+		t.Fatalf("input was modified")
+	}
+
+	_, err = form.Validate(map[string]interface{}{
+		"address": map[string]interface{}{"city": "B", "countyr": "DE"},
+		"items":   []interface{}{map[string]interface{}{"price": 1.0, "colour": "red"}, map[string]interface{}{"price": "foo"}},
+		"other":   true,
+	})
+
+	if formError, ok := err.(*FormError); !ok {
+		t.Fatalf("expected a form error but got %v", err)
+	} else {
+		errors := formError.Errors()
+		// unknown keys below the roots of paths are rejected as well
+		for _, key := range []string{"address.city", "address.countyr", "items[0].colour", "items[1].price", "other"} {
+			if _, ok := errors[key]; !ok {
+				t.Fatalf("expected an error for '%s' but got %v", key, errors)
+			}
+		}
+		if len(errors) != 5 {
+			t.Fatalf("expected five errors but got %v", errors)
+		}
+		if errors["address.countyr"] != "field is unexpected (did you mean 'country'?)" {
+			t.Fatalf("expected a suggestion but got %v", errors["address.countyr"])
+		}
+	}
+
+	// parent values of paths must be maps or lists
+	for _, testCase := range []struct {
+		Input map[string]interface{}
+		Key   string
+		Error string
+	}{
+		{map[string]interface{}{"address": "foo"}, "address", "expected a map"},
+		{map[string]interface{}{"address": map[string]interface{}{"city": "Berlin"}, "items": "foo"}, "items", "expected a list"},
+		{map[string]interface{}{"address": map[string]interface{}{"city": "Berlin"}, "items": []interface{}{4}}, "items[0]", "expected a map"},
+	} {
+		_, err := form.Validate(testCase.Input)
+		if formError, ok := err.(*FormError); !ok {
+			t.Fatalf("expected a form error but got %v", err)
+		} else if errors := formError.Errors(); errors[testCase.Key] != testCase.Error {
+			t.Fatalf("expected the error '%s' for '%s' but got %v", testCase.Error, testCase.Key, errors)
+		}
+	}
+
+	// unknown keys below the roots of paths are stripped in other forms
+	nonStrictForm := &Form{Fields: form.Fields}
+
+	if values, err := nonStrictForm.Validate(map[string]interface{}{
+		"address": map[string]interface{}{"city": "Berlin", "zip": "10115"},
+		"items":   items,
+	}); err != nil {
+		t.Fatal(err)
+	} else if _, ok := values["address"].(map[string]interface{})["zip"]; ok {
+		t.Fatalf("expected the unknown key to be stripped")
+	}
+
+	// keys that contain dots are still supported
+	dottedForm := &Form{
+		Fields: []Field{
+			{
+				Name:       "user.name",
+				Validators: []Validator{IsString{}},
+			},
+		},
+	}
+
+	if values, err := dottedForm.Validate(map[string]interface{}{"user.name": "max"}); err != nil {
+		t.Fatal(err)
+	} else if values["user.name"] != "max" {
+		t.Fatalf("expected a literal key")
+	}
+}
+
+func TestParseFieldPath(t *testing.T) {
+	for _, testCase := range []struct {
+		Name     string
+		Segments int
+		Valid    bool
+	}{
+		{"a.b", 2, true},
+		{"items[*].price", 3, true},
+		{"matrix[0][*]", 3, true},
+		{"a..b", 0, false},
+		{"[*].b", 0, false},
+		{"a[x]", 0, false},
+		{"a[", 0, false},
+		{"a[-1]", 0, false},
+	} {
+		segments, ok := parseFieldPath(testCase.Name)
+		if ok != testCase.Valid {
+			t.Fatalf("%s: expected valid=%t", testCase.Name, testCase.Valid)
+		} else if len(segments) != testCase.Segments {
+			t.Fatalf("%s: expected %d segments but got %d", testCase.Name, testCase.Segments, len(segments))
+		}
+	}
+}
@@ -0,0 +1,278 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"sync"
+)
+
+// validator names consist of an optional dot-separated namespace and a
+// name, e.g. "IsString" or "acme.IsTenantID"
+var validatorNameRegexp = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*\.)*[A-Za-z_][A-Za-z0-9_]*$`)
+
+type registryAlias struct {
+	Name       string
+	Deprecated bool
+}
+
+// Registry maps validator names to their definitions. It is safe for
+// concurrent use. Names can be namespaced (e.g. "acme.IsTenantID") to avoid
+// collisions between libraries, and aliases can be used to rename validators
+// while keeping old names working. Validator types can be associated with
+// names (see RegisterType), which determines how validators are serialized.
+type Registry struct {
+	mutex       sync.RWMutex
+	definitions map[string]ValidatorDefinition
+	aliases     map[string]*registryAlias
+	typeNames   map[reflect.Type]string
+}
+
+func validatorType(validator Validator) reflect.Type {
+	t := reflect.TypeOf(validator)
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
+
+// ValidatorName returns the name under which the type of the given validator
+// was registered in DefaultRegistry, or its struct name if it is unknown.
+func ValidatorName(validator Validator) string {
+	return DefaultRegistry.ValidatorName(validator)
+}
+
+// DefaultRegistry contains the built-in validators. It is used if a
+// FormDescriptionContext does not specify validators.
+var DefaultRegistry = NewRegistry()
+
+func init() {
+	for name, definition := range Validators {
+		if err := DefaultRegistry.Register(name, definition); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		definitions: map[string]ValidatorDefinition{},
+		aliases:     map[string]*registryAlias{},
+		typeNames:   map[reflect.Type]string{},
+	}
+}
+
+// Register adds a validator definition under the given (possibly namespaced)
+// name. Names cannot be registered twice.
+func (r *Registry) Register(name string, definition ValidatorDefinition) error {
+
+	if !validatorNameRegexp.MatchString(name) {
+		return fmt.Errorf("invalid validator name: '%s'", name)
+	}
+
+	if definition.Maker == nil {
+		return fmt.Errorf("validator '%s' has no maker", name)
+	}
+
+	r.mutex.Lock()
+
+	if _, ok := r.definitions[name]; ok {
+		r.mutex.Unlock()
+		return fmt.Errorf("validator '%s' is already registered", name)
+	}
+
+	if _, ok := r.aliases[name]; ok {
+		r.mutex.Unlock()
+		return fmt.Errorf("'%s' is already registered as an alias", name)
+	}
+
+	r.definitions[name] = definition
+
+	r.mutex.Unlock()
+
+	return nil
+}
+
+// RegisterType associates the type of the given validator (e.g.
+// IsTenantID{}) with a registered name, so that validators of this type are
+// serialized with that name. Without it, the struct name of the validator is
+// used (which is the registered name for all built-in validators).
+func (r *Registry) RegisterType(name string, validator Validator) error {
+
+	if validator == nil {
+		return fmt.Errorf("no validator given")
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.definitions[name]; !ok {
+		return fmt.Errorf("unknown validator: '%s'", name)
+	}
+
+	r.typeNames[validatorType(validator)] = name
+
+	return nil
+}
+
+// ValidatorName returns the name under which the type of the given validator
+// was registered via RegisterType. Otherwise, the name from DefaultRegistry
+// or the struct name of the validator is returned.
+func (r *Registry) ValidatorName(validator Validator) string {
+
+	r.mutex.RLock()
+	name, ok := r.typeNames[validatorType(validator)]
+	r.mutex.RUnlock()
+
+	if ok {
+		return name
+	} else if r != DefaultRegistry {
+		return DefaultRegistry.ValidatorName(validator)
+	}
+
+	return GetType(validator)
+}
+
+// RegisterNamespace registers the given definitions with the namespace
+// prepended to their names (e.g. "acme" and "IsTenantID" become
+// "acme.IsTenantID").
+func (r *Registry) RegisterNamespace(namespace string, definitions map[string]ValidatorDefinition) error {
+
+	names := []string{}
+
+	for name := range definitions {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := r.Register(namespace+"."+name, definitions[name]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Alias makes a registered validator available under another name. If
+// deprecated is set, Resolve reports the alias as deprecated.
+func (r *Registry) Alias(alias, name string, deprecated bool) error {
+
+	if !validatorNameRegexp.MatchString(alias) {
+		return fmt.Errorf("invalid validator name: '%s'", alias)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, ok := r.definitions[name]; !ok {
+		return fmt.Errorf("unknown validator: '%s'", name)
+	}
+
+	if _, ok := r.definitions[alias]; ok {
+		return fmt.Errorf("validator '%s' is already registered", alias)
+	}
+
+	if _, ok := r.aliases[alias]; ok {
+		return fmt.Errorf("'%s' is already registered as an alias", alias)
+	}
+
+	r.aliases[alias] = &registryAlias{Name: name, Deprecated: deprecated}
+
+	return nil
+}
+
+// Resolve returns the name of the validator that the given name or alias
+// refers to, and whether the given name is a deprecated alias.
+func (r *Registry) Resolve(name string) (resolvedName string, deprecated bool, ok bool) {
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if _, ok := r.definitions[name]; ok {
+		return name, false, true
+	}
+
+	if alias, ok := r.aliases[name]; ok {
+		return alias.Name, alias.Deprecated, true
+	}
+
+	return "", false, false
+}
+
+// Lookup returns the definition for the given name or alias.
+func (r *Registry) Lookup(name string) (ValidatorDefinition, bool) {
+
+	resolvedName, _, ok := r.Resolve(name)
+
+	if !ok {
+		return ValidatorDefinition{}, false
+	}
+
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	definition, ok := r.definitions[resolvedName]
+
+	return definition, ok
+}
+
+// Names returns the sorted names of all registered validators (without
+// aliases).
+func (r *Registry) Names() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	names := make([]string, 0, len(r.definitions))
+
+	for name := range r.definitions {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Definitions returns a copy of the registered definitions (without
+// aliases).
+func (r *Registry) Definitions() map[string]ValidatorDefinition {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	definitions := make(map[string]ValidatorDefinition, len(r.definitions))
+
+	for name, definition := range r.definitions {
+		definitions[name] = definition
+	}
+
+	return definitions
+}
+
+// Make creates a validator from the definition with the given name.
+func (r *Registry) Make(name string, config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+
+	definition, ok := r.Lookup(name)
+
+	if !ok {
+		return nil, fmt.Errorf("unknown validator type: '%s'", name)
+	}
+
+	return definition.Maker(config, context)
+}
@@ -0,0 +1,183 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type isTenantID struct {
+	Prefix string `json:"prefix"`
+}
+
+func (f isTenantID) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+	if str, ok := input.(string); !ok || !strings.HasPrefix(str, f.Prefix) {
+		return nil, fmt.Errorf("not a tenant ID")
+	}
+	return input, nil
+}
+
+var isTenantIDForm = Form{
+	Fields: []Field{
+		{
+			Name: "prefix",
+			Validators: []Validator{
+				IsOptional{Default: "t-"},
+				IsString{},
+			},
+		},
+	},
+}
+
+func makeIsTenantIDValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+	validator := &isTenantID{}
+	if params, err := isTenantIDForm.Validate(config); err != nil {
+		return nil, err
+	} else if err := isTenantIDForm.Coerce(validator, params); err != nil {
+		return nil, err
+	}
+	return validator, nil
+}
+
+func TestRegistry(t *testing.T) {
+
+	registry := NewRegistry()
+
+	if err := registry.RegisterNamespace("acme", map[string]ValidatorDefinition{
+		"IsTenantID": {makeIsTenantIDValidator, isTenantIDForm},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, definition := range Validators {
+		if err := registry.Register(name, definition); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := registry.Register("IsString", Validators["IsString"]); err == nil {
+		t.Fatalf("expected an error when registering a name twice")
+	}
+
+	if err := registry.Register("acme..IsFoo", Validators["IsString"]); err == nil {
+		t.Fatalf("expected an error for an invalid name")
+	}
+
+	if err := registry.Alias("acme.TenantID", "acme.IsTenantID", true); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := registry.Alias("acme.Foo", "acme.IsFoo", false); err == nil {
+		t.Fatalf("expected an error for an alias of an unknown validator")
+	}
+
+	if name, deprecated, ok := registry.Resolve("acme.TenantID"); !ok || !deprecated || name != "acme.IsTenantID" {
+		t.Fatalf("expected the alias to resolve to acme.IsTenantID, got '%s'", name)
+	}
+
+	if err := registry.RegisterType("acme.IsTenantID", isTenantID{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := registry.RegisterType("acme.IsFoo", isTenantID{}); err == nil {
+		t.Fatalf("expected an error for an unknown validator")
+	}
+
+	deprecatedNames := []string{}
+
+	context := &FormDescriptionContext{
+		Registry: registry,
+		OnDeprecatedName: func(name, replacement string) {
+			deprecatedNames = append(deprecatedNames, name+"->"+replacement)
+		},
+	}
+
+	validator, err := ValidatorFromDescription(&ValidatorDescription{Type: "acme.TenantID", Config: map[string]interface{}{"prefix": "x-"}}, context)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(deprecatedNames) != 1 || deprecatedNames[0] != "acme.TenantID->acme.IsTenantID" {
+		t.Fatalf("expected the deprecated name to be reported, got %v", deprecatedNames)
+	}
+
+	// validators are serialized under their canonical name, also if they
+	// were not created through the registry
+	for _, v := range []Validator{validator, isTenantID{Prefix: "x-"}} {
+		if description, err := registry.SerializeValidator(v); err != nil {
+			t.Fatal(err)
+		} else if description.Type != "acme.IsTenantID" {
+			t.Fatalf("expected type 'acme.IsTenantID', got '%s'", description.Type)
+		}
+	}
+
+	// nested validators are serialized with the names of the registry
+	if description, err := registry.SerializeValidator(IsList{Validators: []Validator{validator}}); err != nil {
+		t.Fatal(err)
+	} else if description.Config["validators"].([]*ValidatorDescription)[0].Type != "acme.IsTenantID" {
+		t.Fatalf("expected the nested validator to be serialized as 'acme.IsTenantID'")
+	}
+
+	// the default registry does not know the name
+	if description, err := SerializeValidator(validator); err != nil {
+		t.Fatal(err)
+	} else if description.Type != "isTenantID" {
+		t.Fatalf("expected type 'isTenantID', got '%s'", description.Type)
+	}
+
+	if _, err := CheckRoundTrip(validator, context); err != nil {
+		t.Fatal(err)
+	}
+
+	// the default registry does not know about the custom validator
+	if _, err := CheckRoundTrip(validator, nil); err == nil {
+		t.Fatalf("expected an error")
+	}
+
+	if _, err := ValidatorFromDescription(&ValidatorDescription{Type: "IsString"}, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRegistryConcurrency(t *testing.T) {
+
+	registry := NewRegistry()
+	wg := sync.WaitGroup{}
+
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			name := fmt.Sprintf("ns%d.IsString", i)
+			if err := registry.Register(name, Validators["IsString"]); err != nil {
+				t.Error(err)
+			}
+			if _, ok := registry.Lookup(name); !ok {
+				t.Errorf("cannot find %s", name)
+			}
+			registry.Names()
+		}(i)
+	}
+
+	wg.Wait()
+
+	if len(registry.Names()) != 20 {
+		t.Fatalf("expected 20 validators, got %d", len(registry.Names()))
+	}
+}
@@ -0,0 +1,163 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// UnknownKeyPolicy determines how a form treats input keys that do not
+// belong to any of its fields.
+type UnknownKeyPolicy string
+
+const (
+	// unknown keys are copied to the validated values
+	AllowUnknownKeys UnknownKeyPolicy = "allow"
+	// unknown keys are removed from the validated values
+	StripUnknownKeys UnknownKeyPolicy = "strip"
+	// unknown keys produce a validation error
+	RejectUnknownKeys UnknownKeyPolicy = "reject"
+)
+
+const unknownKeysContextKey = "_unknownKeys"
+
+// returns the unknown key policy of the form. If the form does not define a
+// policy, Strict forms reject unknown keys and other forms inherit the
+// policy of their parent form (stripping unknown keys by default).
+func (f *Form) unknownKeyPolicy(context map[string]interface{}) UnknownKeyPolicy {
+	if f.UnknownKeys != "" {
+		return f.UnknownKeys
+	} else if f.Strict {
+		return RejectUnknownKeys
+	} else if policy, ok := context[unknownKeysContextKey].(UnknownKeyPolicy); ok {
+		return policy
+	}
+	return StripUnknownKeys
+}
+
+// returns true if the key belongs to one of the fields of the form
+func (f *Form) knowsKey(key string) bool {
+	for _, field := range f.Fields {
+		if field.Name == "*" || field.matches(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// returns the error for an unknown key, which includes the most similar
+// field name if it is close enough to be a likely typo
+func (f *Form) unknownKeyError(key string) error {
+	names := []string{}
+	for _, field := range f.Fields {
+		names = append(append(names, fieldRoot(field.Name)), field.Aliases...)
+	}
+	return unknownKeyError(key, names)
+}
+
+func unknownKeyError(key string, names []string) error {
+	suggestion := ""
+	bestDistance := 0
+	for _, name := range names {
+		if name == "*" {
+			continue
+		}
+		distance := editDistance(key, name)
+		// we allow one edit for short names and up to three for long ones
+		maxDistance := 1 + len(name)/5
+		if maxDistance > 3 {
+			maxDistance = 3
+		}
+		if distance <= maxDistance && (suggestion == "" || distance < bestDistance) {
+			suggestion, bestDistance = name, distance
+		}
+	}
+	if suggestion != "" {
+		return fmt.Errorf("field is unexpected (did you mean '%s'?)", suggestion)
+	}
+	return fmt.Errorf("field is unexpected")
+}
+
+// adds errors for keys in the value of the given input key that are not
+// addressed by any path field of the form (e.g. "address.zip" if the form
+// only has the fields "address.city" and "address.country"). Values that are
+// validated as a whole by a field are not checked.
+func (f *Form) checkUnknownPathKeys(key string, value interface{}, setError func(string, error)) {
+	paths := [][]pathSegment{}
+	for _, field := range f.Fields {
+		if field.Name == "*" || field.Name == key || field.hasAlias(key) {
+			return
+		} else if fieldRoot(field.Name) != key {
+			continue
+		}
+		if segments, ok := parseFieldPath(field.Name); ok && len(segments) > 1 {
+			paths = append(paths, segments[1:])
+		}
+	}
+	if len(paths) > 0 {
+		checkUnknownPathKeys(value, paths, []interface{}{key}, setError)
+	}
+}
+
+func checkUnknownPathKeys(value interface{}, paths [][]pathSegment, path []interface{}, setError func(string, error)) {
+
+	// returns the remaining segments of the paths whose first segment
+	// matches, or true if one of the paths ends with the matching segment
+	match := func(matches func(pathSegment) bool) ([][]pathSegment, bool) {
+		remaining := [][]pathSegment{}
+		for _, segments := range paths {
+			if !matches(segments[0]) {
+				continue
+			} else if len(segments) == 1 {
+				return nil, true
+			}
+			remaining = append(remaining, segments[1:])
+		}
+		return remaining, false
+	}
+
+	extend := func(key interface{}) []interface{} {
+		newPath := make([]interface{}, len(path), len(path)+1)
+		copy(newPath, path)
+		return append(newPath, key)
+	}
+
+	if m, ok := value.(map[string]interface{}); ok {
+		names := []string{}
+		for _, segments := range paths {
+			if !segments[0].IsIndex && !segments[0].Wildcard {
+				names = append(names, segments[0].Key)
+			}
+		}
+		for k, entry := range m {
+			remaining, whole := match(func(s pathSegment) bool { return !s.IsIndex && !s.Wildcard && s.Key == k })
+			if whole {
+				continue
+			} else if len(remaining) == 0 {
+				setError(formatFieldPath(extend(k)), unknownKeyError(k, names))
+				continue
+			}
+			checkUnknownPathKeys(entry, remaining, extend(k), setError)
+		}
+	} else if v := reflect.ValueOf(value); value != nil && v.Kind() == reflect.Slice {
+		// list entries that are not addressed by any path are not checked
+		for i := 0; i < v.Len(); i++ {
+			if remaining, whole := match(func(s pathSegment) bool { return s.Wildcard || (s.IsIndex && s.Index == i) }); !whole && len(remaining) > 0 {
+				checkUnknownPathKeys(v.Index(i).Interface(), remaining, extend(i), setError)
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnknownKeyPolicies(t *testing.T) {
+
+	addressForm := &Form{
+		Fields: []Field{
+			{
+				Name:       "city",
+				Validators: []Validator{IsString{}},
+			},
+		},
+	}
+
+	makeForm := func(policy UnknownKeyPolicy) *Form {
+		return &Form{
+			UnknownKeys: policy,
+			Fields: []Field{
+				{
+					Name:       "name",
+					Validators: []Validator{IsString{}},
+				},
+				{
+					Name:       "address",
+					Validators: []Validator{IsStringMap{Form: addressForm}},
+				},
+			},
+		}
+	}
+
+	input := map[string]interface{}{
+		"name":    "Max",
+		"naem":    "Max",
+		"address": map[string]interface{}{"city": "Berlin", "zip": "10115"},
+	}
+
+	if values, err := makeForm(AllowUnknownKeys).Validate(input); err != nil {
+		t.Fatal(err)
+	} else if values["naem"] != "Max" || values["address"].(map[string]interface{})["zip"] != "10115" {
+		t.Fatalf("expected unknown keys to be kept but got %v", values)
+	}
+
+	if values, err := makeForm(StripUnknownKeys).Validate(input); err != nil {
+		t.Fatal(err)
+	} else if _, ok := values["naem"]; ok {
+		t.Fatalf("expected unknown keys to be removed")
+	} else if _, ok := values["address"].(map[string]interface{})["zip"]; ok {
+		t.Fatalf("expected nested unknown keys to be removed")
+	}
+
+	_, err := makeForm(RejectUnknownKeys).Validate(input)
+
+	if formError, ok := err.(*FormError); !ok {
+		t.Fatalf("expected a form error but got %v", err)
+	} else {
+		errors := formError.Errors()
+		if msg, ok := errors["naem"].(string); !ok || !strings.Contains(msg, "did you mean 'name'") {
+			t.Fatalf("expected a suggestion but got %v", errors["naem"])
+		}
+		if addressError, ok := errors["address"].(*FormError); !ok {
+			t.Fatalf("expected a nested error but got %v", errors["address"])
+		} else if msg, ok := addressError.Errors()["zip"].(string); !ok || strings.Contains(msg, "did you mean") {
+			t.Fatalf("did not expect a suggestion but got %v", addressError.Errors()["zip"])
+		}
+	}
+
+	// the policy is inherited, so the nested form must not have a policy
+	if addressForm.UnknownKeys != "" {
+		t.Fatalf("nested form was modified")
+	}
+
+	// wildcard fields accept all keys
+	wildcardForm := &Form{
+		Strict: true,
+		Fields: []Field{
+			{
+				Name:       "*",
+				Validators: []Validator{IsString{}},
+			},
+		},
+	}
+
+	if _, err := wildcardForm.Validate(map[string]interface{}{"foo": "bar"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the policy is serialized
+	if recoveredForm, err := CheckFormRoundTrip(makeForm(RejectUnknownKeys), nil); err != nil {
+		t.Fatal(err)
+	} else if recoveredForm.UnknownKeys != RejectUnknownKeys {
+		t.Fatalf("expected the policy to be serialized")
+	}
+}
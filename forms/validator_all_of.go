@@ -0,0 +1,102 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"fmt"
+)
+
+var AllOfForm = Form{
+	Fields: []Field{
+		{
+			Name: "options",
+			Validators: []Validator{
+				IsOptional{Default: []map[string]any{}},
+				IsList{
+					Validators: []Validator{
+						IsList{
+							Validators: []Validator{
+								IsStringMap{
+									Form: &ValidatorDescriptionForm,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func (f AllOf) Serialize() (map[string]interface{}, error) {
+	return f.serialize(DefaultRegistry.serializer())
+}
+
+func (f AllOf) serialize(s *serializer) (map[string]interface{}, error) {
+	if optionDescriptions, err := serializeOptions(f.Options, s); err != nil {
+		return nil, err
+	} else {
+		return map[string]interface{}{
+			"options": optionDescriptions,
+		}, nil
+	}
+}
+
+func MakeAllOfValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+	allOf := &AllOf{}
+	if params, err := AllOfForm.Validate(config); err != nil {
+		return nil, err
+	} else if err := AllOfForm.Coerce(allOf, params); err != nil {
+		return nil, err
+	} else if options, err := makeOptions(allOf.OptionsDescriptions, context); err != nil {
+		return nil, err
+	} else {
+		allOf.Options = options
+	}
+	return allOf, nil
+}
+
+// AllOf checks that all options (chains of validators) succeed. Each option
+// receives the original input, the value of the last option is returned.
+// The errors of all failing options are returned in a FormError, keyed by
+// the index of the option.
+type AllOf struct {
+	OptionsDescriptions [][]*ValidatorDescription `json:"options"`
+	Options             [][]Validator             `json:"-"`
+}
+
+func (f AllOf) Validate(input interface{}, inputs map[string]interface{}) (interface{}, error) {
+	return f.validate(input, inputs, nil)
+}
+
+func (f AllOf) ValidateWithContext(input interface{}, inputs map[string]interface{}, context map[string]interface{}) (interface{}, error) {
+	return f.validate(input, inputs, context)
+}
+
+func (f AllOf) validate(input interface{}, inputs map[string]interface{}, context map[string]interface{}) (interface{}, error) {
+	errors := map[string]interface{}{}
+	result := input
+	for i, option := range f.Options {
+		if value, err := validateOption(option, input, inputs, context); err != nil {
+			errors[fmt.Sprintf("%d", i)] = err
+		} else {
+			result = value
+		}
+	}
+	if len(errors) > 0 {
+		return nil, MakeFormError("not all options matched", "FORM-ERROR", errors, nil)
+	}
+	return result, nil
+}
@@ -0,0 +1,98 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"fmt"
+	"time"
+)
+
+var GeneralizeDateForm = Form{
+	Fields: []Field{
+		{
+			Name: "granularity",
+			Validators: []Validator{
+				IsOptional{Default: "month"},
+				IsIn{Choices: []interface{}{"day", "month", "year"}},
+			},
+		},
+	},
+}
+
+func MakeGeneralizeDateValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+	generalizeDate := &GeneralizeDate{}
+	if params, err := GeneralizeDateForm.Validate(config); err != nil {
+		return nil, err
+	} else if err := GeneralizeDateForm.Coerce(generalizeDate, params); err != nil {
+		return nil, err
+	}
+	return generalizeDate, nil
+}
+
+// GeneralizeDate reduces the precision of a date to the given granularity.
+// time.Time values (e.g. produced by IsTime) are truncated and returned as
+// time.Time, strings are returned in a correspondingly shortened format
+// (e.g. "2006-01" for a monthly granularity).
+type GeneralizeDate struct {
+	Granularity string `json:"granularity"`
+}
+
+func (f GeneralizeDate) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+
+	var t time.Time
+	var err error
+	isString := false
+
+	switch v := input.(type) {
+	case time.Time:
+		t = v
+	case *time.Time:
+		if v == nil {
+			return nil, fmt.Errorf("GeneralizeDate: expected a date")
+		}
+		t = *v
+	case string:
+		isString = true
+		if t, err = time.Parse(time.RFC3339, v); err != nil {
+			if t, err = time.Parse("2006-01-02", v); err != nil {
+				return nil, fmt.Errorf("GeneralizeDate: not a valid date")
+			}
+		}
+	default:
+		return nil, fmt.Errorf("GeneralizeDate: expected a date")
+	}
+
+	var format string
+
+	switch f.Granularity {
+	case "day":
+		t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+		format = "2006-01-02"
+	case "", "month":
+		t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+		format = "2006-01"
+	case "year":
+		t = time.Date(t.Year(), 1, 1, 0, 0, 0, 0, t.Location())
+		format = "2006"
+	default:
+		return nil, fmt.Errorf("invalid granularity: %s", f.Granularity)
+	}
+
+	if isString {
+		return t.Format(format), nil
+	}
+
+	return t, nil
+}
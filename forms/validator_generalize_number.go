@@ -0,0 +1,94 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"fmt"
+	"math"
+)
+
+var GeneralizeNumberForm = Form{
+	Fields: []Field{
+		{
+			Name: "bucketSize",
+			Validators: []Validator{
+				IsFloat{HasMin: true, Min: 0},
+			},
+		},
+		{
+			Name: "offset",
+			Validators: []Validator{
+				IsOptional{Default: float64(0)},
+				IsFloat{},
+			},
+		},
+	},
+}
+
+func MakeGeneralizeNumberValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+	generalizeNumber := &GeneralizeNumber{}
+	if params, err := GeneralizeNumberForm.Validate(config); err != nil {
+		return nil, err
+	} else if err := GeneralizeNumberForm.Coerce(generalizeNumber, params); err != nil {
+		return nil, err
+	}
+	if generalizeNumber.BucketSize <= 0 {
+		return nil, fmt.Errorf("bucket size must be larger than 0")
+	}
+	return generalizeNumber, nil
+}
+
+// GeneralizeNumber maps a number to the lower bound of the bucket of size
+// BucketSize it falls into (buckets start at Offset). Integers stay integers
+// as long as the bucket size and offset are integers as well.
+type GeneralizeNumber struct {
+	BucketSize float64 `json:"bucketSize"`
+	Offset     float64 `json:"offset"`
+}
+
+func (f GeneralizeNumber) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+
+	if f.BucketSize <= 0 {
+		return nil, fmt.Errorf("GeneralizeNumber: invalid bucket size")
+	}
+
+	var v float64
+	isInteger := false
+
+	switch n := input.(type) {
+	case int:
+		v, isInteger = float64(n), true
+	case int64:
+		v, isInteger = float64(n), true
+	case float32:
+		v = float64(n)
+	case float64:
+		v = n
+	default:
+		return nil, fmt.Errorf("GeneralizeNumber: expected a number")
+	}
+
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil, fmt.Errorf("GeneralizeNumber: expected a finite number")
+	}
+
+	bucket := math.Floor((v-f.Offset)/f.BucketSize)*f.BucketSize + f.Offset
+
+	if isInteger && bucket == math.Trunc(bucket) {
+		return int64(bucket), nil
+	}
+
+	return bucket, nil
+}
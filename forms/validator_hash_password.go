@@ -0,0 +1,283 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+)
+
+const (
+	DefaultArgon2Time       = 1
+	DefaultArgon2Memory     = 64 * 1024
+	DefaultArgon2Threads    = 4
+	DefaultArgon2KeyLength  = 32
+	DefaultArgon2SaltLength = 16
+)
+
+var HashPasswordForm = Form{
+	Fields: []Field{
+		{
+			Name: "algorithm",
+			Validators: []Validator{
+				IsOptional{Default: "bcrypt"},
+				IsIn{Choices: []interface{}{"bcrypt", "argon2id"}},
+			},
+		},
+		{
+			Name: "cost",
+			Validators: []Validator{
+				IsOptional{Default: int64(bcrypt.DefaultCost)},
+				IsInteger{HasMin: true, Min: int64(bcrypt.MinCost), HasMax: true, Max: int64(bcrypt.MaxCost)},
+			},
+		},
+		{
+			Name: "time",
+			Validators: []Validator{
+				IsOptional{Default: int64(DefaultArgon2Time)},
+				IsInteger{HasMin: true, Min: 1},
+			},
+		},
+		{
+			Name: "memory",
+			Validators: []Validator{
+				IsOptional{Default: int64(DefaultArgon2Memory)},
+				IsInteger{HasMin: true, Min: 8},
+			},
+		},
+		{
+			Name: "threads",
+			Validators: []Validator{
+				IsOptional{Default: int64(DefaultArgon2Threads)},
+				IsInteger{HasMin: true, Min: 1, HasMax: true, Max: 255},
+			},
+		},
+		{
+			Name: "keyLength",
+			Validators: []Validator{
+				IsOptional{Default: int64(DefaultArgon2KeyLength)},
+				IsInteger{HasMin: true, Min: 16, HasMax: true, Max: 1024},
+			},
+		},
+		{
+			Name: "saltLength",
+			Validators: []Validator{
+				IsOptional{Default: int64(DefaultArgon2SaltLength)},
+				IsInteger{HasMin: true, Min: 8, HasMax: true, Max: 1024},
+			},
+		},
+	},
+}
+
+func MakeHashPasswordValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+	hashPassword := &HashPassword{}
+	if params, err := HashPasswordForm.Validate(config); err != nil {
+		return nil, err
+	} else if err := HashPasswordForm.Coerce(hashPassword, params); err != nil {
+		return nil, err
+	}
+	return hashPassword, nil
+}
+
+// HashPassword turns a (previously validated) plaintext password into an
+// encoded bcrypt or argon2id hash. Parameters that are left at their zero
+// value are replaced by sensible defaults.
+type HashPassword struct {
+	Algorithm  string `json:"algorithm"`
+	Cost       int    `json:"cost" coerce:"convert"`
+	Time       uint32 `json:"time" coerce:"convert"`
+	Memory     uint32 `json:"memory" coerce:"convert"`
+	Threads    uint8  `json:"threads" coerce:"convert"`
+	KeyLength  uint32 `json:"keyLength" coerce:"convert"`
+	SaltLength uint32 `json:"saltLength" coerce:"convert"`
+}
+
+// returns a copy of the validator with all default values filled in
+func (f HashPassword) withDefaults() HashPassword {
+	if f.Algorithm == "" {
+		f.Algorithm = "bcrypt"
+	}
+	if f.Cost == 0 {
+		f.Cost = bcrypt.DefaultCost
+	}
+	if f.Time == 0 {
+		f.Time = DefaultArgon2Time
+	}
+	if f.Memory == 0 {
+		f.Memory = DefaultArgon2Memory
+	}
+	if f.Threads == 0 {
+		f.Threads = DefaultArgon2Threads
+	}
+	if f.KeyLength == 0 {
+		f.KeyLength = DefaultArgon2KeyLength
+	}
+	if f.SaltLength == 0 {
+		f.SaltLength = DefaultArgon2SaltLength
+	}
+	return f
+}
+
+func (f HashPassword) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+	password, ok := input.(string)
+	if !ok {
+		return nil, fmt.Errorf("HashPassword: expected a string")
+	}
+	return f.Hash(password)
+}
+
+// Hash returns the encoded hash of the given password.
+func (f HashPassword) Hash(password string) (string, error) {
+	p := f.withDefaults()
+	switch p.Algorithm {
+	case "bcrypt":
+		if hash, err := bcrypt.GenerateFromPassword([]byte(password), p.Cost); err != nil {
+			return "", err
+		} else {
+			return string(hash), nil
+		}
+	case "argon2id":
+		salt := make([]byte, p.SaltLength)
+		if _, err := rand.Read(salt); err != nil {
+			return "", err
+		}
+		key := argon2.IDKey([]byte(password), salt, p.Time, p.Memory, p.Threads, p.KeyLength)
+		return encodeArgon2Hash(&argon2Hash{
+			Version: argon2.Version,
+			Time:    p.Time,
+			Memory:  p.Memory,
+			Threads: p.Threads,
+			Salt:    salt,
+			Key:     key,
+		}), nil
+	default:
+		return "", fmt.Errorf("unknown hashing algorithm: %s", p.Algorithm)
+	}
+}
+
+// NeedsRehash checks whether the given hash was generated with a different
+// algorithm or different parameters than the ones configured in the validator.
+func (f HashPassword) NeedsRehash(hash string) (bool, error) {
+	p := f.withDefaults()
+	switch {
+	case isBcryptHash(hash):
+		if p.Algorithm != "bcrypt" {
+			return true, nil
+		}
+		cost, err := bcrypt.Cost([]byte(hash))
+		if err != nil {
+			return false, err
+		}
+		return cost != p.Cost, nil
+	case isArgon2Hash(hash):
+		if p.Algorithm != "argon2id" {
+			return true, nil
+		}
+		h, err := decodeArgon2Hash(hash)
+		if err != nil {
+			return false, err
+		}
+		return h.Version != argon2.Version ||
+			h.Time != p.Time ||
+			h.Memory != p.Memory ||
+			h.Threads != p.Threads ||
+			uint32(len(h.Key)) != p.KeyLength ||
+			uint32(len(h.Salt)) != p.SaltLength, nil
+	default:
+		return false, fmt.Errorf("unknown hash format")
+	}
+}
+
+// VerifyPassword checks a plaintext password against a bcrypt or argon2id
+// hash as generated by the HashPassword validator.
+func VerifyPassword(password, hash string) (bool, error) {
+	switch {
+	case isBcryptHash(hash):
+		if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err == bcrypt.ErrMismatchedHashAndPassword {
+			return false, nil
+		} else if err != nil {
+			return false, err
+		}
+		return true, nil
+	case isArgon2Hash(hash):
+		h, err := decodeArgon2Hash(hash)
+		if err != nil {
+			return false, err
+		}
+		key := argon2.IDKey([]byte(password), h.Salt, h.Time, h.Memory, h.Threads, uint32(len(h.Key)))
+		return subtle.ConstantTimeCompare(key, h.Key) == 1, nil
+	default:
+		return false, fmt.Errorf("unknown hash format")
+	}
+}
+
+func isBcryptHash(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+func isArgon2Hash(hash string) bool {
+	return strings.HasPrefix(hash, "$argon2id$")
+}
+
+type argon2Hash struct {
+	Version int
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+	Salt    []byte
+	Key     []byte
+}
+
+// we use the PHC string format that is also used by the reference implementation
+func encodeArgon2Hash(h *argon2Hash) string {
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		h.Version,
+		h.Memory,
+		h.Time,
+		h.Threads,
+		base64.RawStdEncoding.EncodeToString(h.Salt),
+		base64.RawStdEncoding.EncodeToString(h.Key),
+	)
+}
+
+func decodeArgon2Hash(hash string) (*argon2Hash, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return nil, fmt.Errorf("invalid argon2id hash")
+	}
+	h := &argon2Hash{}
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &h.Version); err != nil {
+		return nil, fmt.Errorf("invalid argon2id version: %v", err)
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &h.Memory, &h.Time, &h.Threads); err != nil {
+		return nil, fmt.Errorf("invalid argon2id parameters: %v", err)
+	}
+	var err error
+	if h.Salt, err = base64.RawStdEncoding.DecodeString(parts[4]); err != nil {
+		return nil, fmt.Errorf("invalid argon2id salt: %v", err)
+	}
+	if h.Key, err = base64.RawStdEncoding.DecodeString(parts[5]); err != nil {
+		return nil, fmt.Errorf("invalid argon2id key: %v", err)
+	}
+	if len(h.Key) == 0 || h.Threads == 0 {
+		return nil, fmt.Errorf("invalid argon2id hash")
+	}
+	return h, nil
+}
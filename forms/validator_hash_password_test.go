@@ -0,0 +1,120 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHashPassword(t *testing.T) {
+	validators := []HashPassword{
+		HashPassword{Algorithm: "bcrypt", Cost: 4},
+		HashPassword{Algorithm: "argon2id", Memory: 1024, Threads: 1},
+	}
+
+	for i, validator := range validators {
+		value, err := validator.Validate("secret", nil)
+		if err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		hash, ok := value.(string)
+		if !ok {
+			t.Fatalf("case %d: expected a string", i)
+		}
+		if ok, err := VerifyPassword("secret", hash); err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		} else if !ok {
+			t.Fatalf("case %d: expected the password to match", i)
+		}
+		if ok, err := VerifyPassword("wrong", hash); err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		} else if ok {
+			t.Fatalf("case %d: expected the password not to match", i)
+		}
+		if rehash, err := validator.NeedsRehash(hash); err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		} else if rehash {
+			t.Fatalf("case %d: expected no rehash to be necessary", i)
+		}
+	}
+
+	bcryptHash, err := validators[0].Hash("secret")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rehash, err := validators[1].NeedsRehash(bcryptHash); err != nil {
+		t.Fatal(err)
+	} else if !rehash {
+		t.Fatalf("expected a rehash when switching algorithms")
+	}
+
+	if rehash, err := (HashPassword{Algorithm: "bcrypt", Cost: 5}).NeedsRehash(bcryptHash); err != nil {
+		t.Fatal(err)
+	} else if !rehash {
+		t.Fatalf("expected a rehash when changing the cost")
+	}
+}
+
+func TestHashPasswordFromConfig(t *testing.T) {
+	config := map[string]interface{}{
+		"fields": []map[string]interface{}{
+			{
+				"name": "password",
+				"validators": []map[string]interface{}{
+					{
+						"type": "IsString",
+						"config": map[string]interface{}{
+							"minLength": 8,
+						},
+					},
+					{
+						"type": "HashPassword",
+						"config": map[string]interface{}{
+							"algorithm": "argon2id",
+							"memory":    1024,
+							"threads":   2,
+						},
+					},
+				},
+			},
+		},
+	}
+	context := &FormDescriptionContext{
+		Validators: Validators,
+	}
+	form, err := FromConfig(config, context)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := form.Validate(map[string]interface{}{"password": "short"}); err == nil {
+		t.Fatalf("expected an error")
+	}
+	params, err := form.Validate(map[string]interface{}{"password": "long enough"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, _ := params["password"].(string)
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=2$") {
+		t.Fatalf("unexpected hash: %s", hash)
+	}
+	if ok, err := VerifyPassword("long enough", hash); err != nil {
+		t.Fatal(err)
+	} else if !ok {
+		t.Fatalf("expected the password to match")
+	}
+}
@@ -0,0 +1,66 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"fmt"
+	"regexp"
+)
+
+var bicRegexp = regexp.MustCompile(`^[A-Z]{4}[A-Z]{2}[A-Z0-9]{2}([A-Z0-9]{3})?$`)
+
+var IsBICForm = Form{
+	Fields: []Field{
+		{
+			Name: "expand",
+			Validators: []Validator{
+				IsOptional{Default: false},
+				IsBoolean{},
+			},
+		},
+	},
+}
+
+func MakeIsBICValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+	isBIC := &IsBIC{}
+	if params, err := IsBICForm.Validate(config); err != nil {
+		return nil, err
+	} else if err := IsBICForm.Coerce(isBIC, params); err != nil {
+		return nil, err
+	}
+	return isBIC, nil
+}
+
+// IsBIC checks that a string is a valid 8 or 11 character BIC (ISO 9362). If
+// Expand is set, 8 character BICs are expanded to 11 characters by appending
+// the primary office branch code "XXX".
+type IsBIC struct {
+	Expand bool `json:"expand"`
+}
+
+func (f IsBIC) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+	str, ok := input.(string)
+	if !ok {
+		return nil, fmt.Errorf("IsBIC: expected a string")
+	}
+	bic := normalizeIdentifier(str, "")
+	if !bicRegexp.MatchString(bic) {
+		return nil, fmt.Errorf("not a valid BIC")
+	}
+	if f.Expand && len(bic) == 8 {
+		bic += "XXX"
+	}
+	return bic, nil
+}
@@ -0,0 +1,187 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var IsCoordinateForm = Form{
+	Fields: []Field{
+		{
+			Name: "order",
+			Validators: []Validator{
+				IsOptional{Default: "latlon"},
+				IsIn{Choices: []interface{}{"latlon", "lonlat"}},
+			},
+		},
+		{
+			Name: "hasPrecision",
+			Validators: []Validator{
+				IsOptional{Default: false},
+				IsBoolean{},
+			},
+		},
+		{
+			Name: "precision",
+			Validators: []Validator{
+				IsOptional{Default: int64(0)},
+				IsInteger{HasMin: true, Min: 0, HasMax: true, Max: 15},
+			},
+		},
+	},
+}
+
+func MakeIsCoordinateValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+	isCoordinate := &IsCoordinate{}
+	if params, err := IsCoordinateForm.Validate(config); err != nil {
+		return nil, err
+	} else if err := IsCoordinateForm.Coerce(isCoordinate, params); err != nil {
+		return nil, err
+	}
+	// giving a precision implies that values should be rounded, unless
+	// hasPrecision is given explicitly
+	_, hasHasPrecision := config["hasPrecision"]
+	if _, ok := config["precision"]; ok && !hasHasPrecision {
+		isCoordinate.HasPrecision = true
+	}
+	return isCoordinate, nil
+}
+
+// IsCoordinate checks for a geographic coordinate and returns it as a
+// {"lat": ..., "lon": ...} map. It accepts maps (with "lat"/"latitude" and
+// "lon"/"lng"/"longitude" keys), lists with two numbers and strings like
+// "52.52, 13.405". For lists and strings, Order determines whether the
+// latitude ("latlon") or the longitude ("lonlat", as in GeoJSON) comes first.
+// If HasPrecision is set, both values are rounded to Precision decimal
+// places.
+type IsCoordinate struct {
+	Order        string `json:"order"`
+	HasPrecision bool   `json:"hasPrecision"`
+	Precision    int    `json:"precision,omitempty" coerce:"convert"`
+}
+
+// converts a number or a numeric string to a finite float
+func coordinateValue(value interface{}) (float64, error) {
+	var v float64
+	switch n := value.(type) {
+	case int:
+		v = float64(n)
+	case int64:
+		v = float64(n)
+	case float32:
+		v = float64(n)
+	case float64:
+		v = n
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(n), 64)
+		if err != nil {
+			return 0, fmt.Errorf("not a number: '%s'", n)
+		}
+		v = f
+	default:
+		return 0, fmt.Errorf("expected a number")
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, fmt.Errorf("expected a finite number")
+	}
+	return v, nil
+}
+
+// checks that a longitude and latitude are within their valid ranges
+func checkCoordinate(lon, lat float64) error {
+	if lat < -90 || lat > 90 {
+		return fmt.Errorf("latitude must be between -90 and 90")
+	}
+	if lon < -180 || lon > 180 {
+		return fmt.Errorf("longitude must be between -180 and 180")
+	}
+	return nil
+}
+
+func (f IsCoordinate) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+
+	var latValue, lonValue interface{}
+
+	// returns the first value that exists under one of the given keys
+	lookup := func(m map[string]interface{}, keys ...string) interface{} {
+		for _, key := range keys {
+			if v, ok := m[key]; ok {
+				return v
+			}
+		}
+		return nil
+	}
+
+	switch v := input.(type) {
+	case map[string]interface{}:
+		latValue = lookup(v, "lat", "latitude")
+		lonValue = lookup(v, "lon", "lng", "longitude")
+		if latValue == nil || lonValue == nil {
+			return nil, fmt.Errorf("expected a latitude and a longitude")
+		}
+	case []interface{}:
+		if len(v) != 2 {
+			return nil, fmt.Errorf("expected a list with two values")
+		}
+		latValue, lonValue = v[0], v[1]
+	case []float64:
+		if len(v) != 2 {
+			return nil, fmt.Errorf("expected a list with two values")
+		}
+		latValue, lonValue = v[0], v[1]
+	case string:
+		parts := strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == ' ' || r == ';' })
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("expected two comma-separated values")
+		}
+		latValue, lonValue = parts[0], parts[1]
+	default:
+		return nil, fmt.Errorf("IsCoordinate: expected a map, list or string")
+	}
+
+	if _, isMap := input.(map[string]interface{}); !isMap && f.Order == "lonlat" {
+		latValue, lonValue = lonValue, latValue
+	}
+
+	lat, err := coordinateValue(latValue)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid latitude: %v", err)
+	}
+
+	lon, err := coordinateValue(lonValue)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid longitude: %v", err)
+	}
+
+	if err := checkCoordinate(lon, lat); err != nil {
+		return nil, err
+	}
+
+	if f.HasPrecision {
+		p := math.Pow10(f.Precision)
+		lat, lon = math.Round(lat*p)/p, math.Round(lon*p)/p
+	}
+
+	return map[string]interface{}{
+		"lat": lat,
+		"lon": lon,
+	}, nil
+}
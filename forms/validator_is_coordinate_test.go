@@ -0,0 +1,142 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsCoordinate(t *testing.T) {
+
+	validator, err := MakeIsCoordinateValidator(map[string]interface{}{"precision": 3}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{"lat": 52.52, "lon": 13.405}
+
+	for _, input := range []interface{}{
+		map[string]interface{}{"lat": 52.52, "lon": 13.405},
+		map[string]interface{}{"latitude": "52.5201", "lng": 13.40499},
+		[]interface{}{52.52, 13.405},
+		"52.52, 13.405",
+	} {
+		if output, err := validator.Validate(input, nil); err != nil {
+			t.Errorf("%v: %v", input, err)
+		} else if !reflect.DeepEqual(output, expected) {
+			t.Errorf("%v: expected %v, got %v", input, expected, output)
+		}
+	}
+
+	if output, err := (IsCoordinate{Order: "lonlat"}).Validate([]interface{}{13.405, 52.52}, nil); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(output, expected) {
+		t.Errorf("expected %v, got %v", expected, output)
+	}
+
+	// a precision of 0 rounds to whole degrees
+	if validator, err := MakeIsCoordinateValidator(map[string]interface{}{"precision": 0}, nil); err != nil {
+		t.Error(err)
+	} else if output, err := validator.Validate([]interface{}{52.52, 13.405}, nil); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(output, map[string]interface{}{"lat": 53.0, "lon": 13.0}) {
+		t.Errorf("expected rounded values, got %v", output)
+	}
+
+	// an explicit hasPrecision is respected
+	for _, validator := range []IsCoordinate{
+		{Order: "latlon", Precision: 3},
+		{Order: "latlon", HasPrecision: true, Precision: 3},
+		{Order: "lonlat", HasPrecision: true},
+	} {
+		if recovered, err := CheckRoundTrip(validator, nil); err != nil {
+			t.Error(err)
+		} else if *recovered.(*IsCoordinate) != validator {
+			t.Errorf("expected %v, got %v", validator, recovered)
+		}
+	}
+
+	for _, input := range []interface{}{
+		map[string]interface{}{"lat": 91, "lon": 0},
+		map[string]interface{}{"lat": 0, "lon": -180.5},
+		map[string]interface{}{"lat": 0},
+		[]interface{}{1.0},
+		"foo, bar",
+		42,
+	} {
+		if _, err := validator.Validate(input, nil); err == nil {
+			t.Errorf("%v: expected an error", input)
+		}
+	}
+}
+
+func TestIsGeoJSON(t *testing.T) {
+
+	ccw := []interface{}{
+		[]interface{}{0.0, 0.0}, []interface{}{10.0, 0.0}, []interface{}{10.0, 10.0}, []interface{}{0.0, 10.0}, []interface{}{0.0, 0.0},
+	}
+	cw := []interface{}{
+		[]interface{}{0.0, 0.0}, []interface{}{0.0, 10.0}, []interface{}{10.0, 10.0}, []interface{}{10.0, 0.0}, []interface{}{0.0, 0.0},
+	}
+	open := []interface{}{
+		[]interface{}{0.0, 0.0}, []interface{}{10.0, 0.0}, []interface{}{10.0, 10.0}, []interface{}{0.0, 10.0},
+	}
+
+	polygon := func(rings ...interface{}) map[string]interface{} {
+		return map[string]interface{}{"type": "Polygon", "coordinates": rings}
+	}
+
+	for i, testCase := range []struct {
+		Validator IsGeoJSON
+		Input     interface{}
+		Valid     bool
+	}{
+		{IsGeoJSON{}, `{"type": "Point", "coordinates": [13.4, 52.5]}`, true},
+		{IsGeoJSON{}, `{"type": "Point", "coordinates": [13.4, 95]}`, false},
+		{IsGeoJSON{}, `{"type": "LineString", "coordinates": [[0, 0], [1, 1]]}`, true},
+		{IsGeoJSON{}, `{"type": "LineString", "coordinates": [[0, 0]]}`, false},
+		{IsGeoJSON{}, `{"type": "MultiPoint", "coordinates": [[0, 0]]}`, false},
+		{IsGeoJSON{Types: []string{"Polygon"}}, `{"type": "Point", "coordinates": [0, 0]}`, false},
+		{IsGeoJSON{}, polygon(cw), true},
+		{IsGeoJSON{Winding: "check"}, polygon(cw), false},
+		{IsGeoJSON{Winding: "check"}, polygon(ccw, cw), true},
+		{IsGeoJSON{Winding: "check"}, polygon(ccw, ccw), false},
+		{IsGeoJSON{}, polygon(open), false},
+		{IsGeoJSON{CloseRings: true}, polygon(open), true},
+	} {
+		_, err := testCase.Validator.Validate(testCase.Input, nil)
+		if testCase.Valid && err != nil {
+			t.Errorf("case %d: %v", i, err)
+		} else if !testCase.Valid && err == nil {
+			t.Errorf("case %d: expected an error", i)
+		}
+	}
+
+	output, err := (IsGeoJSON{Winding: "fix"}).Validate(polygon(cw), nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if coordinates := output.(map[string]interface{})["coordinates"]; !reflect.DeepEqual(coordinates, []interface{}{ccw}) {
+		t.Errorf("expected the ring to be reversed, got %v", coordinates)
+	}
+
+	if _, err := MakeIsGeoJSONValidator(map[string]interface{}{"types": []interface{}{"Circle"}}, nil); err == nil {
+		t.Errorf("expected an error for an unknown geometry type")
+	}
+}
@@ -0,0 +1,98 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"fmt"
+	"strings"
+)
+
+var IsCountryCodeForm = Form{
+	Fields: []Field{
+		{
+			Name: "format",
+			Validators: []Validator{
+				IsOptional{Default: "any"},
+				IsIn{Choices: []interface{}{"any", "alpha2", "alpha3"}},
+			},
+		},
+		{
+			Name: "convertTo",
+			Validators: []Validator{
+				IsOptional{},
+				IsIn{Choices: []interface{}{"alpha2", "alpha3"}},
+			},
+		},
+	},
+}
+
+func MakeIsCountryCodeValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+	isCountryCode := &IsCountryCode{}
+	if params, err := IsCountryCodeForm.Validate(config); err != nil {
+		return nil, err
+	} else if err := IsCountryCodeForm.Coerce(isCountryCode, params); err != nil {
+		return nil, err
+	}
+	return isCountryCode, nil
+}
+
+// IsCountryCode checks for an ISO 3166-1 alpha-2 or alpha-3 country code
+// (depending on Format) and returns it in upper case, optionally converted
+// to the format given by ConvertTo.
+type IsCountryCode struct {
+	Format    string `json:"format"`
+	ConvertTo string `json:"convertTo,omitempty"`
+}
+
+func (f IsCountryCode) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+	str, ok := input.(string)
+	if !ok {
+		return nil, fmt.Errorf("IsCountryCode: expected a string")
+	}
+	return convertCode(strings.ToUpper(strings.TrimSpace(str)), CountryCodes, countryCodesAlpha3, f.Format, f.ConvertTo, "country")
+}
+
+// checks a code against alpha-2 and alpha-3 code tables and converts it to
+// the desired format
+func convertCode(code string, alpha2 map[string]string, alpha3 map[string]string, format, convertTo, kind string) (string, error) {
+	switch len(code) {
+	case 2:
+		if format == "alpha3" {
+			return "", fmt.Errorf("expected an alpha-3 %s code", kind)
+		}
+		alpha3Code, ok := alpha2[code]
+		if !ok {
+			return "", fmt.Errorf("unknown %s code: '%s'", kind, code)
+		}
+		if convertTo == "alpha3" {
+			return alpha3Code, nil
+		}
+		return code, nil
+	case 3:
+		if format == "alpha2" {
+			return "", fmt.Errorf("expected an alpha-2 %s code", kind)
+		}
+		alpha2Code, ok := alpha3[code]
+		if !ok {
+			return "", fmt.Errorf("unknown %s code: '%s'", kind, code)
+		}
+		if convertTo == "alpha2" {
+			return alpha2Code, nil
+		}
+		return code, nil
+	default:
+		return "", fmt.Errorf("not a valid %s code", kind)
+	}
+}
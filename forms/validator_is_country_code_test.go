@@ -0,0 +1,74 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"testing"
+)
+
+func TestLocaleValidators(t *testing.T) {
+	for i, testCase := range []struct {
+		Validator Validator
+		Input     interface{}
+		Output    interface{}
+	}{
+		{IsCountryCode{}, "de", "DE"},
+		{IsCountryCode{}, "deu", "DEU"},
+		{IsCountryCode{ConvertTo: "alpha3"}, "de", "DEU"},
+		{IsCountryCode{ConvertTo: "alpha2"}, "GBR", "GB"},
+		{IsLanguageCode{}, "EN", "en"},
+		{IsLanguageCode{ConvertTo: "alpha3"}, "de", "deu"},
+		{IsLanguageCode{ConvertTo: "alpha2"}, "ger", "de"},
+		{IsCurrencyCode{}, "eur", "EUR"},
+		{IsLanguageTag{}, "de_de", "de-DE"},
+		{IsLanguageTag{}, "ZH-hant-tw", "zh-Hant-TW"},
+		{IsLanguageTag{}, "es-419", "es-419"},
+		{IsLanguageTag{}, "sl-rozaj-biske", "sl-rozaj-biske"},
+		{IsLanguageTag{}, "en-US-u-ca-gregory-x-test", "en-US-u-ca-gregory-x-test"},
+		{IsLanguageTag{}, "yue-HK", "yue-HK"},
+		{IsLanguageTag{}, "x-whatever", "x-whatever"},
+	} {
+		output, err := testCase.Validator.Validate(testCase.Input, nil)
+		if err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		if output != testCase.Output {
+			t.Fatalf("case %d: expected '%v', got '%v'", i, testCase.Output, output)
+		}
+	}
+
+	for i, testCase := range []struct {
+		Validator Validator
+		Input     interface{}
+	}{
+		{IsCountryCode{}, "XX"},
+		{IsCountryCode{Format: "alpha2"}, "DEU"},
+		{IsCountryCode{Format: "alpha3"}, "DE"},
+		{IsLanguageCode{}, "qq"},
+		{IsCurrencyCode{}, "EURO"},
+		{IsCurrencyCode{}, "ABC"},
+		{IsLanguageTag{}, "de-XX"},
+		{IsLanguageTag{}, "qq-DE"},
+		{IsLanguageTag{Strict: true}, "yue-HK"},
+		{IsLanguageTag{}, "en-u"},
+		{IsLanguageTag{}, "en-a-foo-a-bar"},
+		{IsLanguageTag{}, "en--US"},
+		{IsLanguageTag{}, "123"},
+	} {
+		if _, err := testCase.Validator.Validate(testCase.Input, nil); err == nil {
+			t.Fatalf("case %d: expected an error", i)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type creditCardBrand struct {
+	Name    string
+	Ranges  [][2]int
+	Lengths [2]int
+}
+
+// card brands with their IIN ranges and allowed number lengths. The order is
+// relevant as more specific ranges need to be checked first.
+var creditCardBrands = []creditCardBrand{
+	{"amex", [][2]int{{34, 34}, {37, 37}}, [2]int{15, 15}},
+	{"diners", [][2]int{{300, 305}, {36, 36}, {38, 39}}, [2]int{14, 19}},
+	{"jcb", [][2]int{{3528, 3589}}, [2]int{16, 19}},
+	{"visa", [][2]int{{4, 4}}, [2]int{13, 19}},
+	{"mastercard", [][2]int{{51, 55}, {2221, 2720}}, [2]int{16, 16}},
+	{"discover", [][2]int{{6011, 6011}, {644, 649}, {65, 65}}, [2]int{16, 19}},
+	{"unionpay", [][2]int{{62, 62}}, [2]int{16, 19}},
+	{"maestro", [][2]int{{50, 50}, {56, 69}}, [2]int{12, 19}},
+}
+
+var creditCardBrandNames = func() []interface{} {
+	names := make([]interface{}, len(creditCardBrands))
+	for i, brand := range creditCardBrands {
+		names[i] = brand.Name
+	}
+	return names
+}()
+
+var IsCreditCardForm = Form{
+	Fields: []Field{
+		{
+			Name: "brands",
+			Validators: []Validator{
+				IsOptional{},
+				IsStringList{
+					Validators: []Validator{
+						IsIn{Choices: creditCardBrandNames},
+					},
+				},
+			},
+		},
+		{
+			Name: "mask",
+			Validators: []Validator{
+				IsOptional{Default: false},
+				IsBoolean{},
+			},
+		},
+	},
+}
+
+func MakeIsCreditCardValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+	isCreditCard := &IsCreditCard{}
+	if params, err := IsCreditCardForm.Validate(config); err != nil {
+		return nil, err
+	} else if err := IsCreditCardForm.Coerce(isCreditCard, params); err != nil {
+		return nil, err
+	}
+	return isCreditCard, nil
+}
+
+// IsCreditCard checks a card number using the Luhn algorithm and the IIN
+// ranges of the supported card brands. The number is returned with all
+// spaces and dashes removed. If Mask is set, all but the last four digits
+// are replaced by asterisks.
+type IsCreditCard struct {
+	Brands []string `json:"brands,omitempty"`
+	Mask   bool     `json:"mask"`
+}
+
+func (f IsCreditCard) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+	str, ok := input.(string)
+	if !ok {
+		return nil, fmt.Errorf("IsCreditCard: expected a string")
+	}
+
+	number := normalizeIdentifier(str, "-")
+
+	if len(number) < 12 || len(number) > 19 {
+		return nil, fmt.Errorf("not a valid card number")
+	}
+
+	for _, c := range number {
+		if c < '0' || c > '9' {
+			return nil, fmt.Errorf("not a valid card number")
+		}
+	}
+
+	if !luhnValid(number) {
+		return nil, fmt.Errorf("invalid card number checksum")
+	}
+
+	if len(f.Brands) > 0 {
+		brand := CreditCardBrand(number)
+		found := false
+		for _, allowedBrand := range f.Brands {
+			if allowedBrand == brand {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("card brand is not supported, must be one of: %s", strings.Join(f.Brands, ", "))
+		}
+	}
+
+	if f.Mask {
+		return maskString(number, 0, 4), nil
+	}
+
+	return number, nil
+}
+
+// CreditCardBrand returns the brand of a (normalized) card number, or an
+// empty string if the brand is unknown.
+func CreditCardBrand(number string) string {
+	for _, brand := range creditCardBrands {
+		if len(number) < brand.Lengths[0] || len(number) > brand.Lengths[1] {
+			continue
+		}
+		for _, r := range brand.Ranges {
+			digits := len(strconv.Itoa(r[0]))
+			if len(number) < digits {
+				continue
+			}
+			prefix, err := strconv.Atoi(number[:digits])
+			if err != nil {
+				continue
+			}
+			if prefix >= r[0] && prefix <= r[1] {
+				return brand.Name
+			}
+		}
+	}
+	return ""
+}
+
+func luhnValid(number string) bool {
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		d := int(number[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
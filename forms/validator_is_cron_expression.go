@@ -0,0 +1,347 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type cronField struct {
+	Name  string
+	Min   int
+	Max   int
+	Names map[string]int
+}
+
+var cronSecondField = cronField{"second", 0, 59, nil}
+var cronMinuteField = cronField{"minute", 0, 59, nil}
+var cronHourField = cronField{"hour", 0, 23, nil}
+var cronDayOfMonthField = cronField{"day of month", 1, 31, nil}
+var cronMonthField = cronField{"month", 1, 12, map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}}
+
+// 7 is an alias for Sunday and is mapped to 0 after parsing
+var cronDayOfWeekField = cronField{"day of week", 0, 7, map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}}
+
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// CronSchedule is a parsed cron expression that can compute the times at
+// which it fires.
+type CronSchedule struct {
+	source                                string
+	second, minute, hour, dom, month, dow uint64
+	domRestricted, dowRestricted          bool
+}
+
+func (s *CronSchedule) String() string {
+	return s.source
+}
+
+func (s *CronSchedule) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(s.source)), nil
+}
+
+// ParseCronExpression parses a cron expression with 5 fields (minute, hour,
+// day of month, month, day of week), 6 fields (with a leading seconds
+// field) or one of the macros @yearly, @annually, @monthly, @weekly, @daily,
+// @midnight and @hourly. The seconds argument can be "optional", "required"
+// or "none" and determines whether 6-field expressions are accepted.
+func ParseCronExpression(expression, seconds string) (*CronSchedule, error) {
+
+	source := strings.TrimSpace(expression)
+	schedule := &CronSchedule{source: source}
+
+	fields := strings.Fields(source)
+
+	if len(fields) == 1 && strings.HasPrefix(fields[0], "@") {
+		macro, ok := cronMacros[strings.ToLower(fields[0])]
+		if !ok {
+			return nil, fmt.Errorf("unknown cron macro: '%s'", fields[0])
+		}
+		fields = strings.Fields(macro)
+	} else {
+		switch len(fields) {
+		case 5:
+			if seconds == "required" {
+				return nil, fmt.Errorf("expected 6 fields (including seconds), got 5")
+			}
+			fields = append([]string{"0"}, fields...)
+		case 6:
+			if seconds == "none" {
+				return nil, fmt.Errorf("expected 5 fields, got 6")
+			}
+		default:
+			return nil, fmt.Errorf("expected 5 or 6 fields, got %d", len(fields))
+		}
+	}
+
+	var err error
+
+	if schedule.second, _, err = parseCronField(fields[0], cronSecondField); err != nil {
+		return nil, err
+	}
+	if schedule.minute, _, err = parseCronField(fields[1], cronMinuteField); err != nil {
+		return nil, err
+	}
+	if schedule.hour, _, err = parseCronField(fields[2], cronHourField); err != nil {
+		return nil, err
+	}
+	if schedule.dom, schedule.domRestricted, err = parseCronField(fields[3], cronDayOfMonthField); err != nil {
+		return nil, err
+	}
+	if schedule.month, _, err = parseCronField(fields[4], cronMonthField); err != nil {
+		return nil, err
+	}
+	if schedule.dow, schedule.dowRestricted, err = parseCronField(fields[5], cronDayOfWeekField); err != nil {
+		return nil, err
+	}
+
+	// Sunday can be given as 0 or 7
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow = (schedule.dow | 1) &^ (1 << 7)
+	}
+
+	return schedule, nil
+}
+
+func parseCronValue(str string, field cronField) (int, error) {
+	if n, ok := field.Names[strings.ToLower(str)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(str)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value: '%s'", field.Name, str)
+	}
+	if n < field.Min || n > field.Max {
+		return 0, fmt.Errorf("%s value %d out of range [%d, %d]", field.Name, n, field.Min, field.Max)
+	}
+	return n, nil
+}
+
+// parses a cron field into a bit set. The second return value indicates
+// whether the field restricts the allowed values (i.e. is not "*" or "?")
+func parseCronField(str string, field cronField) (uint64, bool, error) {
+	var bits uint64
+	restricted := true
+
+	for _, part := range strings.Split(str, ",") {
+
+		rangeStr, stepStr := part, ""
+
+		if i := strings.Index(part, "/"); i >= 0 {
+			rangeStr, stepStr = part[:i], part[i+1:]
+		}
+
+		start, end := field.Min, field.Max
+
+		switch {
+		case rangeStr == "*" || rangeStr == "?":
+			if stepStr == "" {
+				restricted = false
+			}
+		case strings.Contains(rangeStr, "-"):
+			bounds := strings.SplitN(rangeStr, "-", 2)
+			var err error
+			if start, err = parseCronValue(bounds[0], field); err != nil {
+				return 0, false, err
+			}
+			if end, err = parseCronValue(bounds[1], field); err != nil {
+				return 0, false, err
+			}
+			if start > end {
+				return 0, false, fmt.Errorf("invalid %s range: '%s'", field.Name, rangeStr)
+			}
+		default:
+			var err error
+			if start, err = parseCronValue(rangeStr, field); err != nil {
+				return 0, false, err
+			}
+			// "a/n" means "from a to the maximum in steps of n"
+			if stepStr == "" {
+				end = start
+			}
+		}
+
+		step := 1
+
+		if stepStr != "" {
+			var err error
+			if step, err = strconv.Atoi(stepStr); err != nil || step <= 0 {
+				return 0, false, fmt.Errorf("invalid %s step: '%s'", field.Name, stepStr)
+			}
+		}
+
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+
+	return bits, restricted, nil
+}
+
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	domMatches := s.dom&(1<<uint(t.Day())) != 0
+	dowMatches := s.dow&(1<<uint(t.Weekday())) != 0
+	// if both day fields are restricted, a day matches if either matches
+	if s.domRestricted && s.dowRestricted {
+		return domMatches || dowMatches
+	}
+	return domMatches && dowMatches
+}
+
+// Next returns the first time after t at which the schedule fires, or the
+// zero time if there is no such time within the next five years.
+func (s *CronSchedule) Next(t time.Time) time.Time {
+
+	loc := t.Location()
+
+	t = t.Add(time.Second - time.Duration(t.Nanosecond())*time.Nanosecond)
+
+	yearLimit := t.Year() + 5
+
+	// this flag tells us whether we've already truncated a lower order field
+	added := false
+
+WRAP:
+	if t.Year() > yearLimit {
+		return time.Time{}
+	}
+
+	for s.month&(1<<uint(t.Month())) == 0 {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc)
+		}
+		t = t.AddDate(0, 1, 0)
+		if t.Month() == time.January {
+			goto WRAP
+		}
+	}
+
+	for !s.dayMatches(t) {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+		}
+		t = t.AddDate(0, 0, 1)
+		if t.Day() == 1 {
+			goto WRAP
+		}
+	}
+
+	for s.hour&(1<<uint(t.Hour())) == 0 {
+		if !added {
+			added = true
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc)
+		}
+		t = t.Add(time.Hour)
+		if t.Hour() == 0 {
+			goto WRAP
+		}
+	}
+
+	for s.minute&(1<<uint(t.Minute())) == 0 {
+		if !added {
+			added = true
+			t = t.Truncate(time.Minute)
+		}
+		t = t.Add(time.Minute)
+		if t.Minute() == 0 {
+			goto WRAP
+		}
+	}
+
+	for s.second&(1<<uint(t.Second())) == 0 {
+		if !added {
+			added = true
+		}
+		t = t.Add(time.Second)
+		if t.Second() == 0 {
+			goto WRAP
+		}
+	}
+
+	return t
+}
+
+var IsCronExpressionForm = Form{
+	Fields: []Field{
+		{
+			Name: "seconds",
+			Validators: []Validator{
+				IsOptional{Default: "optional"},
+				IsIn{Choices: []interface{}{"optional", "required", "none"}},
+			},
+		},
+		{
+			Name: "parse",
+			Validators: []Validator{
+				IsOptional{Default: false},
+				IsBoolean{},
+			},
+		},
+	},
+}
+
+func MakeIsCronExpressionValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+	isCronExpression := &IsCronExpression{}
+	if params, err := IsCronExpressionForm.Validate(config); err != nil {
+		return nil, err
+	} else if err := IsCronExpressionForm.Coerce(isCronExpression, params); err != nil {
+		return nil, err
+	}
+	return isCronExpression, nil
+}
+
+// IsCronExpression checks that a string is a valid cron expression. If Parse
+// is set, a *CronSchedule is returned instead of the (trimmed) string.
+type IsCronExpression struct {
+	Seconds string `json:"seconds"`
+	Parse   bool   `json:"parse"`
+}
+
+func (f IsCronExpression) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+	str, ok := input.(string)
+	if !ok {
+		return nil, fmt.Errorf("IsCronExpression: expected a string")
+	}
+
+	schedule, err := ParseCronExpression(str, f.Seconds)
+
+	if err != nil {
+		return nil, fmt.Errorf("not a valid cron expression: %v", err)
+	}
+
+	if f.Parse {
+		return schedule, nil
+	}
+
+	return schedule.String(), nil
+}
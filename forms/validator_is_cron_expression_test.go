@@ -0,0 +1,81 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	// 2024-03-15 is a Friday
+	start := time.Date(2024, 3, 15, 10, 30, 15, 500, time.UTC)
+
+	for i, testCase := range []struct {
+		Expression string
+		Next       time.Time
+	}{
+		{"* * * * *", time.Date(2024, 3, 15, 10, 31, 0, 0, time.UTC)},
+		{"* * * * * *", time.Date(2024, 3, 15, 10, 30, 16, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 3, 15, 10, 45, 0, 0, time.UTC)},
+		{"0 9-17 * * mon-fri", time.Date(2024, 3, 15, 11, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, 3, 17, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1,15 * *", time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 13 * 5", time.Date(2024, 3, 22, 0, 0, 0, 0, time.UTC)},
+		{"30 5 29 feb *", time.Date(2028, 2, 29, 5, 30, 0, 0, time.UTC)},
+		{"@daily", time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 31 2 *", time.Time{}},
+	} {
+		schedule, err := ParseCronExpression(testCase.Expression, "optional")
+		if err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		if next := schedule.Next(start); !next.Equal(testCase.Next) {
+			t.Fatalf("case %d (%s): expected %v, got %v", i, testCase.Expression, testCase.Next, next)
+		}
+	}
+}
+
+func TestIsCronExpression(t *testing.T) {
+	for i, testCase := range []struct {
+		Validator IsCronExpression
+		Input     string
+		Valid     bool
+	}{
+		{IsCronExpression{}, "5 4 * * sun", true},
+		{IsCronExpression{}, "0 5 4 * * sun", true},
+		{IsCronExpression{}, "@hourly", true},
+		{IsCronExpression{Seconds: "required"}, "5 4 * * sun", false},
+		{IsCronExpression{Seconds: "none"}, "0 5 4 * * sun", false},
+		{IsCronExpression{}, "60 * * * *", false},
+		{IsCronExpression{}, "* * * *", false},
+		{IsCronExpression{}, "5-1 * * * *", false},
+		{IsCronExpression{}, "*/0 * * * *", false},
+		{IsCronExpression{}, "@fortnightly", false},
+	} {
+		if _, err := testCase.Validator.Validate(testCase.Input, nil); testCase.Valid && err != nil {
+			t.Fatalf("case %d should be valid but raised: %v", i, err)
+		} else if !testCase.Valid && err == nil {
+			t.Fatalf("case %d should raise an error but didn't", i)
+		}
+	}
+
+	if value, err := (IsCronExpression{Parse: true}).Validate("@daily", nil); err != nil {
+		t.Fatal(err)
+	} else if _, ok := value.(*CronSchedule); !ok {
+		t.Fatalf("expected a cron schedule")
+	}
+}
@@ -0,0 +1,50 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"fmt"
+	"strings"
+)
+
+var IsCurrencyCodeForm = Form{
+	Fields: []Field{},
+}
+
+func MakeIsCurrencyCodeValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+	isCurrencyCode := &IsCurrencyCode{}
+	if params, err := IsCurrencyCodeForm.Validate(config); err != nil {
+		return nil, err
+	} else if err := IsCurrencyCodeForm.Coerce(isCurrencyCode, params); err != nil {
+		return nil, err
+	}
+	return isCurrencyCode, nil
+}
+
+// IsCurrencyCode checks for an ISO 4217 currency code and returns it in
+// upper case.
+type IsCurrencyCode struct{}
+
+func (f IsCurrencyCode) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+	str, ok := input.(string)
+	if !ok {
+		return nil, fmt.Errorf("IsCurrencyCode: expected a string")
+	}
+	code := strings.ToUpper(strings.TrimSpace(str))
+	if !CurrencyCodes[code] {
+		return nil, fmt.Errorf("unknown currency code: '%s'", code)
+	}
+	return code, nil
+}
@@ -0,0 +1,206 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kiprotect/go-helpers/maps"
+	"github.com/kiprotect/go-helpers/yaml"
+)
+
+var IsEncodedDocumentForm = Form{
+	Fields: []Field{
+		{
+			Name: "format",
+			Validators: []Validator{
+				IsOptional{Default: "json"},
+				IsIn{Choices: []interface{}{"json", "yaml"}},
+			},
+		},
+		{
+			Name: "form",
+			Validators: []Validator{
+				IsOptional{},
+				IsFormDescription{},
+			},
+		},
+		{
+			Name: "validators",
+			Validators: []Validator{
+				IsOptional{},
+				IsList{
+					Validators: []Validator{
+						IsStringMap{
+							Form: &ValidatorDescriptionForm,
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func (f IsEncodedDocument) Serialize() (map[string]interface{}, error) {
+	return f.serialize(DefaultRegistry.serializer())
+}
+
+func (f IsEncodedDocument) serialize(s *serializer) (map[string]interface{}, error) {
+	config := map[string]interface{}{
+		"format": f.Format,
+	}
+	if f.Form != nil {
+		if form, err := serializeFormOrRef(f.Form, f.Ref, s); err != nil {
+			return nil, err
+		} else {
+			config["form"] = form
+		}
+	}
+	if f.Validators != nil {
+		if validators, err := s.serializeValidators(f.Validators); err != nil {
+			return nil, err
+		} else {
+			config["validators"] = validators
+		}
+	}
+	return config, nil
+}
+
+func MakeIsEncodedDocumentValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+	isEncodedDocument := &IsEncodedDocument{}
+	if params, err := IsEncodedDocumentForm.Validate(config); err != nil {
+		return nil, err
+	} else {
+		// the form is created separately, as it might be a reference
+		documentParams := map[string]interface{}{}
+		for key, value := range params {
+			if key != "form" {
+				documentParams[key] = value
+			}
+		}
+		if err := IsEncodedDocumentForm.Coerce(isEncodedDocument, documentParams); err != nil {
+			return nil, err
+		}
+		if isEncodedDocument.Form, isEncodedDocument.Ref, err = formFromParams(params["form"], context); err != nil {
+			return nil, err
+		}
+		if isEncodedDocument.ValidatorDescriptions != nil {
+			validators := []Validator{}
+			for i, validatorDescription := range isEncodedDocument.ValidatorDescriptions {
+				if validator, err := ValidatorFromDescription(validatorDescription, context); err != nil {
+					return nil, makeConfigError(fmt.Sprintf("validators[%d]", i), err)
+				} else {
+					validators = append(validators, validator)
+				}
+			}
+			isEncodedDocument.Validators = validators
+		}
+	}
+	return isEncodedDocument, nil
+}
+
+// IsEncodedDocument decodes a JSON or YAML encoded string and validates the
+// result with the given form (which requires the document to be a map) and/or
+// the given validators. It returns the decoded and validated value. Ref is
+// the name of the named form that Form refers to (if any).
+type IsEncodedDocument struct {
+	Format                string                  `json:"format"`
+	Form                  *Form                   `json:"form,omitempty"`
+	Ref                   string                  `json:"-"`
+	Validators            []Validator             `json:"-"`
+	ValidatorDescriptions []*ValidatorDescription `json:"validators"`
+}
+
+func (f IsEncodedDocument) ValidateWithContext(input interface{}, values map[string]interface{}, context map[string]interface{}) (interface{}, error) {
+	return f.validate(input, values, context)
+}
+
+func (f IsEncodedDocument) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+	return f.validate(input, values, nil)
+}
+
+func (f IsEncodedDocument) decode(input interface{}) (interface{}, error) {
+
+	var data []byte
+
+	switch v := input.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return nil, fmt.Errorf("IsEncodedDocument: expected a string")
+	}
+
+	var document interface{}
+
+	switch f.Format {
+	case "", "json":
+		if err := json.Unmarshal(data, &document); err != nil {
+			return nil, fmt.Errorf("invalid JSON document: %v", err)
+		}
+	case "yaml":
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			return nil, fmt.Errorf("invalid YAML document: %v", err)
+		}
+		// the YAML parser produces map[interface{}]interface{} maps
+		if stringDocument, ok := maps.EnsureStringKeys(document); !ok {
+			return nil, fmt.Errorf("invalid YAML document: only string keys are supported")
+		} else {
+			document = stringDocument
+		}
+	default:
+		return nil, fmt.Errorf("invalid format: %s", f.Format)
+	}
+
+	return document, nil
+}
+
+func (f IsEncodedDocument) validate(input interface{}, values map[string]interface{}, context map[string]interface{}) (interface{}, error) {
+
+	document, err := f.decode(input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if f.Form != nil {
+		documentMap, ok := document.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("document is not a map")
+		}
+		if context == nil {
+			context = map[string]interface{}{"_parent": values}
+		} else {
+			context["_parent"] = values
+		}
+		if document, err = f.Form.ValidateWithContext(documentMap, context); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, validator := range f.Validators {
+		if contextValidator, ok := validator.(ContextValidator); ok && context != nil {
+			document, err = contextValidator.ValidateWithContext(document, values, context)
+		} else {
+			document, err = validator.Validate(document, values)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return document, nil
+}
@@ -0,0 +1,202 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestIsEncodedDocumentFromConfig(t *testing.T) {
+	for _, format := range []string{"json", "yaml"} {
+		config := map[string]interface{}{
+			"fields": []map[string]interface{}{
+				{
+					"name": "payload",
+					"validators": []map[string]interface{}{
+						{
+							"type": "IsEncodedDocument",
+							"config": map[string]interface{}{
+								"format": format,
+								"form": map[string]interface{}{
+									"fields": []map[string]interface{}{
+										{
+											"name": "event",
+											"validators": []map[string]interface{}{
+												{
+													"type": "IsString",
+												},
+											},
+										},
+										{
+											"name": "count",
+											"validators": []map[string]interface{}{
+												{
+													"type": "IsInteger",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		context := &FormDescriptionContext{
+			Validators: Validators,
+		}
+		form, err := FromConfig(config, context)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var valid, invalid string
+
+		if format == "json" {
+			valid = `{"event": "created", "count": 3}`
+			invalid = `{"event": 4, "count": 3}`
+		} else {
+			valid = "event: created\ncount: 3\n"
+			invalid = "event: created\ncount: three\n"
+		}
+
+		params, err := form.Validate(map[string]interface{}{"payload": valid})
+		if err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+
+		payload, ok := params["payload"].(map[string]interface{})
+		if !ok {
+			t.Fatalf("%s: expected a map", format)
+		}
+
+		if payload["event"] != "created" || payload["count"] != int64(3) {
+			t.Fatalf("%s: unexpected payload: %v", format, payload)
+		}
+
+		if _, err := form.Validate(map[string]interface{}{"payload": invalid}); err == nil {
+			t.Fatalf("%s: expected an error", format)
+		}
+
+		if _, err := form.Validate(map[string]interface{}{"payload": "{"}); err == nil {
+			t.Fatalf("%s: expected a decoding error", format)
+		}
+
+		if _, err := json.Marshal(form); err != nil {
+			t.Fatalf("%s: %v", format, err)
+		}
+	}
+}
+
+func TestIsEncodedDocumentWithValidators(t *testing.T) {
+	validator := IsEncodedDocument{
+		Validators: []Validator{
+			IsList{
+				Validators: []Validator{
+					IsString{},
+				},
+			},
+		},
+	}
+
+	if value, err := validator.Validate(`["a", "b"]`, nil); err != nil {
+		t.Fatal(err)
+	} else if list, ok := value.([]interface{}); !ok || len(list) != 2 {
+		t.Fatalf("expected a list with two elements")
+	}
+
+	if _, err := validator.Validate(`["a", 1]`, nil); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
+
+func TestIsEncodedDocumentWithNamedForms(t *testing.T) {
+
+	nodeForm := map[string]interface{}{
+		"fields": []interface{}{
+			map[string]interface{}{
+				"name":       "label",
+				"validators": []interface{}{map[string]interface{}{"type": "IsString"}},
+			},
+			map[string]interface{}{
+				"name": "children",
+				"validators": []interface{}{
+					map[string]interface{}{"type": "IsOptional"},
+					map[string]interface{}{
+						"type": "IsList",
+						"config": map[string]interface{}{
+							"validators": []interface{}{
+								map[string]interface{}{
+									"type":   "IsStringMap",
+									"config": map[string]interface{}{"form": map[string]interface{}{"$ref": "Node"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	documentField := func(form map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"name": "tree",
+			"validators": []interface{}{
+				map[string]interface{}{
+					"type":   "IsEncodedDocument",
+					"config": map[string]interface{}{"form": form},
+				},
+			},
+		}
+	}
+
+	for _, config := range []map[string]interface{}{
+		// a reference to a named form of the enclosing form
+		{
+			"forms":  map[string]interface{}{"Node": nodeForm},
+			"fields": []interface{}{documentField(map[string]interface{}{"$ref": "Node"})},
+		},
+		// an embedded form that declares the named form itself
+		{
+			"fields": []interface{}{documentField(map[string]interface{}{
+				"forms":  map[string]interface{}{"Node": nodeForm},
+				"fields": nodeForm["fields"],
+			})},
+		},
+	} {
+		form, err := FromConfig(config, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		recoveredForm, err := CheckFormRoundTrip(form, nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, f := range []*Form{form, recoveredForm} {
+			if _, err := f.Validate(map[string]interface{}{"tree": `{"label": "a", "children": [{"label": "b"}]}`}); err != nil {
+				t.Fatalf("expected no error but got %v", err)
+			}
+			if _, err := f.Validate(map[string]interface{}{"tree": `{"label": "a", "children": [{"label": 4}]}`}); err == nil {
+				t.Fatalf("expected an error for an invalid child")
+			}
+		}
+	}
+}
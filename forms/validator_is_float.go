@@ -16,6 +16,7 @@ package forms
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 )
 
@@ -46,14 +47,49 @@ var IsFloatForm = Form{
 			Name: "min",
 			Validators: []Validator{
 				IsOptional{},
-				IsFloat{HasMin: true, Min: 0},
+				IsFloat{},
 			},
 		},
 		{
 			Name: "max",
 			Validators: []Validator{
 				IsOptional{},
-				IsFloat{HasMin: true, Min: 0},
+				IsFloat{},
+			},
+		},
+		{
+			Name: "exclusiveMin",
+			Validators: []Validator{
+				IsOptional{Default: false},
+				IsBoolean{},
+			},
+		},
+		{
+			Name: "exclusiveMax",
+			Validators: []Validator{
+				IsOptional{Default: false},
+				IsBoolean{},
+			},
+		},
+		{
+			Name: "multipleOf",
+			Validators: []Validator{
+				IsOptional{},
+				IsFloat{HasMin: true, Min: 0, ExclusiveMin: true},
+			},
+		},
+		{
+			Name: "type",
+			Validators: []Validator{
+				IsOptional{},
+				IsIn{Choices: []interface{}{"float32", "float64"}},
+			},
+		},
+		{
+			Name: "locale",
+			Validators: []Validator{
+				IsOptional{},
+				IsString{},
 			},
 		},
 	},
@@ -66,15 +102,31 @@ func MakeIsFloatValidator(config map[string]interface{}, context *FormDescriptio
 	} else if err := IsFloatForm.Coerce(isFloat, params); err != nil {
 		return nil, err
 	}
+	if isFloat.Locale != "" {
+		if _, ok := lookupNumberLocale(isFloat.Locale); !ok {
+			return nil, fmt.Errorf("unsupported locale: '%s'", isFloat.Locale)
+		}
+	}
 	return isFloat, nil
 }
 
+// IsFloat checks for a finite floating point number (NaN and infinite values
+// are rejected). Min and Max are inclusive bounds unless ExclusiveMin or
+// ExclusiveMax are set. If Type is "float32", values outside of the float32
+// range are rejected and the value is returned as a float32, otherwise it is
+// returned as a float64. If Convert is set, strings are converted as well,
+// using the decimal and grouping separators of Locale if given.
 type IsFloat struct {
-	Convert bool    `json:"convert,omitempty"`
-	Min     float64 `json:"min,omitempty" coerce:"convert"`
-	Max     float64 `json:"max,omitempty" coerce:"convert"`
-	HasMin  bool    `json:"hasMin,omitempty"`
-	HasMax  bool    `json:"hasMax,omitempty"`
+	Convert      bool    `json:"convert,omitempty"`
+	Min          float64 `json:"min,omitempty" coerce:"convert"`
+	Max          float64 `json:"max,omitempty" coerce:"convert"`
+	HasMin       bool    `json:"hasMin,omitempty"`
+	HasMax       bool    `json:"hasMax,omitempty"`
+	ExclusiveMin bool    `json:"exclusiveMin,omitempty"`
+	ExclusiveMax bool    `json:"exclusiveMax,omitempty"`
+	MultipleOf   float64 `json:"multipleOf,omitempty" coerce:"convert"`
+	Type         string  `json:"type,omitempty"`
+	Locale       string  `json:"locale,omitempty"`
 }
 
 func (f IsFloat) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
@@ -86,25 +138,69 @@ func (f IsFloat) Validate(input interface{}, values map[string]interface{}) (int
 		iv = float64(v)
 	case int:
 		iv = float64(v)
+	case int8:
+		iv = float64(v)
+	case int16:
+		iv = float64(v)
+	case int32:
+		iv = float64(v)
 	case int64:
 		iv = float64(v)
+	case uint:
+		iv = float64(v)
+	case uint8:
+		iv = float64(v)
+	case uint16:
+		iv = float64(v)
+	case uint32:
+		iv = float64(v)
+	case uint64:
+		iv = float64(v)
 	case string:
 		if !f.Convert {
-			return nil, fmt.Errorf("not an integer")
+			return nil, fmt.Errorf("not a float")
+		}
+		str, err := normalizeNumber(v, f.Locale)
+		if err != nil {
+			return nil, err
 		}
-		i, err := strconv.ParseFloat(v, 64)
+		i, err := strconv.ParseFloat(str, 64)
 		if err != nil {
-			return nil, fmt.Errorf("not an integer")
+			return nil, fmt.Errorf("not a float")
 		}
 		iv = i
 	default:
-		return nil, fmt.Errorf("not an float")
+		return nil, fmt.Errorf("not a float")
+	}
+	if math.IsNaN(iv) || math.IsInf(iv, 0) {
+		return nil, fmt.Errorf("value must be a finite number")
 	}
-	if f.HasMin && iv < f.Min {
-		return nil, fmt.Errorf("value must be larger than or equal %g", f.Min)
+	if f.HasMin {
+		if f.ExclusiveMin && iv <= f.Min {
+			return nil, fmt.Errorf("value must be larger than %g", f.Min)
+		} else if iv < f.Min {
+			return nil, fmt.Errorf("value must be larger than or equal %g", f.Min)
+		}
 	}
-	if f.HasMax && iv > f.Max {
-		return nil, fmt.Errorf("value must be smaller than or equal %g", f.Max)
+	if f.HasMax {
+		if f.ExclusiveMax && iv >= f.Max {
+			return nil, fmt.Errorf("value must be smaller than %g", f.Max)
+		} else if iv > f.Max {
+			return nil, fmt.Errorf("value must be smaller than or equal %g", f.Max)
+		}
+	}
+	if f.MultipleOf > 0 {
+		// we allow for a small relative error as e.g. 0.3 / 0.1 != 3
+		q := iv / f.MultipleOf
+		if math.Abs(q-math.Round(q)) > 1e-9*math.Max(1, math.Abs(q)) {
+			return nil, fmt.Errorf("value must be a multiple of %g", f.MultipleOf)
+		}
+	}
+	if f.Type == "float32" {
+		if math.Abs(iv) > math.MaxFloat32 {
+			return nil, fmt.Errorf("value does not fit into float32")
+		}
+		return float32(iv), nil
 	}
 	return iv, nil
 }
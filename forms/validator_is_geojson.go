@@ -0,0 +1,234 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+var geoJSONTypes = []interface{}{"Point", "LineString", "Polygon"}
+
+var IsGeoJSONForm = Form{
+	Fields: []Field{
+		{
+			Name: "types",
+			Validators: []Validator{
+				IsOptional{},
+				IsStringList{Validators: []Validator{IsIn{Choices: geoJSONTypes}}},
+			},
+		},
+		{
+			Name: "closeRings",
+			Validators: []Validator{
+				IsOptional{Default: false},
+				IsBoolean{},
+			},
+		},
+		{
+			Name: "winding",
+			Validators: []Validator{
+				IsOptional{Default: "any"},
+				IsIn{Choices: []interface{}{"any", "check", "fix"}},
+			},
+		},
+	},
+}
+
+func MakeIsGeoJSONValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+	isGeoJSON := &IsGeoJSON{}
+	if params, err := IsGeoJSONForm.Validate(config); err != nil {
+		return nil, err
+	} else if err := IsGeoJSONForm.Coerce(isGeoJSON, params); err != nil {
+		return nil, err
+	}
+	return isGeoJSON, nil
+}
+
+// IsGeoJSON checks for a GeoJSON geometry (RFC 7946) of type Point,
+// LineString or Polygon, given either as a map or as a JSON string. If Types
+// is given, only these geometry types are accepted. Polygon rings must be
+// closed unless CloseRings is set, in which case open rings are closed
+// automatically. Winding determines how the orientation of rings is handled:
+// "any" (or "") accepts all orientations, "check" requires counterclockwise exterior
+// rings and clockwise holes (as recommended by the RFC) and "fix" reverses
+// rings with the wrong orientation. The geometry is returned as a map with
+// "type" and "coordinates" keys.
+type IsGeoJSON struct {
+	Types      []string `json:"types,omitempty"`
+	CloseRings bool     `json:"closeRings"`
+	Winding    string   `json:"winding"`
+}
+
+// validates a position (a list of two or three numbers)
+func geoJSONPosition(value interface{}) ([]interface{}, error) {
+	list, ok := value.([]interface{})
+	if !ok || len(list) < 2 || len(list) > 3 {
+		return nil, fmt.Errorf("a position must be a list with two or three numbers")
+	}
+	position := make([]interface{}, len(list))
+	for i, v := range list {
+		if _, isString := v.(string); isString {
+			return nil, fmt.Errorf("invalid position: expected a number")
+		}
+		f, err := coordinateValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid position: %v", err)
+		}
+		position[i] = f
+	}
+	if err := checkCoordinate(position[0].(float64), position[1].(float64)); err != nil {
+		return nil, err
+	}
+	return position, nil
+}
+
+func geoJSONPositions(value interface{}, minLength int) ([]interface{}, error) {
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a list of positions")
+	}
+	if len(list) < minLength {
+		return nil, fmt.Errorf("expected at least %d positions", minLength)
+	}
+	positions := make([]interface{}, len(list))
+	for i, v := range list {
+		position, err := geoJSONPosition(v)
+		if err != nil {
+			return nil, fmt.Errorf("position %d: %v", i, err)
+		}
+		positions[i] = position
+	}
+	return positions, nil
+}
+
+func samePosition(a, b []interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// returns twice the signed area of a ring (shoelace formula), which is
+// positive for counterclockwise rings
+func ringArea(ring []interface{}) float64 {
+	area := 0.0
+	for i := 0; i < len(ring)-1; i++ {
+		a, b := ring[i].([]interface{}), ring[i+1].([]interface{})
+		area += a[0].(float64)*b[1].(float64) - b[0].(float64)*a[1].(float64)
+	}
+	return area
+}
+
+func (f IsGeoJSON) polygon(value interface{}) ([]interface{}, error) {
+	list, ok := value.([]interface{})
+	if !ok || len(list) == 0 {
+		return nil, fmt.Errorf("a polygon must be a non-empty list of rings")
+	}
+	rings := make([]interface{}, len(list))
+	for i, v := range list {
+		ring, err := geoJSONPositions(v, 3)
+		if err != nil {
+			return nil, fmt.Errorf("ring %d: %v", i, err)
+		}
+		if !samePosition(ring[0].([]interface{}), ring[len(ring)-1].([]interface{})) {
+			if !f.CloseRings {
+				return nil, fmt.Errorf("ring %d is not closed", i)
+			}
+			ring = append(ring, ring[0])
+		}
+		if len(ring) < 4 {
+			return nil, fmt.Errorf("ring %d: a ring must have at least four positions", i)
+		}
+		// the exterior ring must be counterclockwise, holes must be clockwise
+		if area := ringArea(ring); f.Winding != "any" && f.Winding != "" && area != 0 && (area > 0) != (i == 0) {
+			if f.Winding == "check" {
+				if i == 0 {
+					return nil, fmt.Errorf("the exterior ring must be counterclockwise")
+				}
+				return nil, fmt.Errorf("ring %d: holes must be clockwise", i)
+			}
+			for j, k := 0, len(ring)-1; j < k; j, k = j+1, k-1 {
+				ring[j], ring[k] = ring[k], ring[j]
+			}
+		}
+		rings[i] = ring
+	}
+	return rings, nil
+}
+
+func (f IsGeoJSON) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+
+	if str, ok := input.(string); ok {
+		var geometry interface{}
+		if err := json.Unmarshal([]byte(str), &geometry); err != nil {
+			return nil, fmt.Errorf("invalid JSON: %v", err)
+		}
+		input = geometry
+	}
+
+	geometry, ok := input.(map[string]interface{})
+
+	if !ok {
+		return nil, fmt.Errorf("IsGeoJSON: expected a map or a JSON string")
+	}
+
+	geometryType, ok := geometry["type"].(string)
+
+	if !ok {
+		return nil, fmt.Errorf("missing geometry type")
+	}
+
+	if len(f.Types) > 0 {
+		found := false
+		for _, allowedType := range f.Types {
+			if allowedType == geometryType {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("geometry type '%s' is not allowed", geometryType)
+		}
+	}
+
+	var coordinates []interface{}
+	var err error
+
+	switch geometryType {
+	case "Point":
+		coordinates, err = geoJSONPosition(geometry["coordinates"])
+	case "LineString":
+		coordinates, err = geoJSONPositions(geometry["coordinates"], 2)
+	case "Polygon":
+		coordinates, err = f.polygon(geometry["coordinates"])
+	default:
+		return nil, fmt.Errorf("unsupported geometry type: '%s'", geometryType)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", geometryType, err)
+	}
+
+	return map[string]interface{}{
+		"type":        geometryType,
+		"coordinates": coordinates,
+	}, nil
+}
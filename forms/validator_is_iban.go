@@ -0,0 +1,157 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// IBAN lengths as defined in the SWIFT IBAN registry
+var IBANLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16,
+	"BG": 22, "BH": 22, "BI": 27, "BR": 29, "BY": 28, "CH": 21, "CR": 22,
+	"CY": 28, "CZ": 24, "DE": 22, "DJ": 27, "DK": 18, "DO": 28, "EE": 20,
+	"EG": 29, "ES": 24, "FI": 18, "FK": 18, "FO": 18, "FR": 27, "GB": 22,
+	"GE": 22, "GI": 23, "GL": 18, "GR": 27, "GT": 28, "HR": 21, "HU": 28,
+	"IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27, "JO": 30, "KW": 30,
+	"KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20, "LV": 21,
+	"LY": 25, "MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20, "MR": 27,
+	"MT": 31, "MU": 30, "NI": 28, "NL": 18, "NO": 15, "OM": 23, "PK": 24,
+	"PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33,
+	"SA": 24, "SC": 31, "SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27,
+	"SO": 23, "ST": 25, "SV": 28, "TL": 23, "TN": 24, "TR": 26, "UA": 29,
+	"VA": 22, "VG": 24, "XK": 20, "YE": 30,
+}
+
+var ibanRegexp = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[A-Z0-9]+$`)
+
+var IsIBANForm = Form{
+	Fields: []Field{
+		{
+			Name: "countries",
+			Validators: []Validator{
+				IsOptional{},
+				IsStringList{},
+			},
+		},
+		{
+			Name: "format",
+			Validators: []Validator{
+				IsOptional{Default: "electronic"},
+				IsIn{Choices: []interface{}{"electronic", "print"}},
+			},
+		},
+		{
+			Name: "mask",
+			Validators: []Validator{
+				IsOptional{Default: false},
+				IsBoolean{},
+			},
+		},
+	},
+}
+
+func MakeIsIBANValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+	isIBAN := &IsIBAN{}
+	if params, err := IsIBANForm.Validate(config); err != nil {
+		return nil, err
+	} else if err := IsIBANForm.Coerce(isIBAN, params); err != nil {
+		return nil, err
+	}
+	return isIBAN, nil
+}
+
+// IsIBAN checks the length and mod-97 checksum of an IBAN. The IBAN is
+// returned without spaces and in upper case ("electronic" format) or in
+// groups of four characters ("print" format). If Mask is set, all but the
+// first and last four characters are replaced by asterisks.
+type IsIBAN struct {
+	Countries []string `json:"countries,omitempty"`
+	Format    string   `json:"format"`
+	Mask      bool     `json:"mask"`
+}
+
+func (f IsIBAN) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+	str, ok := input.(string)
+	if !ok {
+		return nil, fmt.Errorf("IsIBAN: expected a string")
+	}
+
+	iban := normalizeIdentifier(str, "-")
+
+	if !ibanRegexp.MatchString(iban) {
+		return nil, fmt.Errorf("not a valid IBAN")
+	}
+
+	country := iban[:2]
+
+	if length, ok := IBANLengths[country]; !ok {
+		return nil, fmt.Errorf("unknown IBAN country code: %s", country)
+	} else if len(iban) != length {
+		return nil, fmt.Errorf("IBANs from %s must be %d characters long", country, length)
+	}
+
+	if len(f.Countries) > 0 {
+		found := false
+		for _, allowedCountry := range f.Countries {
+			if strings.ToUpper(allowedCountry) == country {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("IBANs from %s are not allowed", country)
+		}
+	}
+
+	if ibanChecksum(iban) != 1 {
+		return nil, fmt.Errorf("invalid IBAN checksum")
+	}
+
+	if f.Mask {
+		iban = maskString(iban, 4, 4)
+	}
+
+	if f.Format == "print" {
+		groups := make([]string, 0, len(iban)/4+1)
+		for i := 0; i < len(iban); i += 4 {
+			end := i + 4
+			if end > len(iban) {
+				end = len(iban)
+			}
+			groups = append(groups, iban[i:end])
+		}
+		return strings.Join(groups, " "), nil
+	}
+
+	return iban, nil
+}
+
+// computes the ISO 7064 mod-97 checksum of an (upper case) IBAN
+func ibanChecksum(iban string) int {
+	rearranged := iban[4:] + iban[:4]
+	remainder := 0
+	for _, c := range rearranged {
+		if c >= 'A' && c <= 'Z' {
+			n := int(c-'A') + 10
+			remainder = (remainder*100 + n) % 97
+		} else {
+			remainder = (remainder*10 + int(c-'0')) % 97
+		}
+	}
+	return remainder
+}
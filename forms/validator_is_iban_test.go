@@ -0,0 +1,86 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"testing"
+)
+
+func TestFinancialIdentifiers(t *testing.T) {
+	for i, testCase := range []struct {
+		Validator Validator
+		Input     interface{}
+		Output    interface{}
+	}{
+		{IsIBAN{}, "DE89 3704 0044 0532 0130 00", "DE89370400440532013000"},
+		{IsIBAN{}, "gb29nwbk60161331926819", "GB29NWBK60161331926819"},
+		{IsIBAN{Format: "print"}, "DE89370400440532013000", "DE89 3704 0044 0532 0130 00"},
+		{IsIBAN{Mask: true}, "DE89370400440532013000", "DE89**************3000"},
+		{IsBIC{}, "deut de ff", "DEUTDEFF"},
+		{IsBIC{Expand: true}, "DEUTDEFF", "DEUTDEFFXXX"},
+		{IsCreditCard{}, "4111 1111 1111 1111", "4111111111111111"},
+		{IsCreditCard{Brands: []string{"amex"}}, "3782-822463-10005", "378282246310005"},
+		{IsCreditCard{Mask: true}, "5555555555554444", "************4444"},
+		{IsVATID{}, "DE 123 456 789", "DE123456789"},
+		{IsVATID{}, "GR123456789", "EL123456789"},
+		{IsVATID{}, "NL123456789B01", "NL123456789B01"},
+	} {
+		output, err := testCase.Validator.Validate(testCase.Input, nil)
+		if err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		if output != testCase.Output {
+			t.Fatalf("case %d: expected '%v', got '%v'", i, testCase.Output, output)
+		}
+	}
+
+	for i, testCase := range []struct {
+		Validator Validator
+		Input     interface{}
+	}{
+		{IsIBAN{}, "DE89370400440532013001"},
+		{IsIBAN{}, "DE8937040044053201300"},
+		{IsIBAN{}, "ZZ89370400440532013000"},
+		{IsIBAN{Countries: []string{"AT"}}, "DE89370400440532013000"},
+		{IsBIC{}, "DEUTDEF"},
+		{IsCreditCard{}, "4111111111111112"},
+		{IsCreditCard{Brands: []string{"visa"}}, "5555555555554444"},
+		{IsVATID{}, "DE12345678"},
+		{IsVATID{}, "US123456789"},
+		{IsVATID{Countries: []string{"AT"}}, "DE123456789"},
+	} {
+		if _, err := testCase.Validator.Validate(testCase.Input, nil); err == nil {
+			t.Fatalf("case %d: expected an error", i)
+		}
+	}
+}
+
+func TestCreditCardBrand(t *testing.T) {
+	for number, brand := range map[string]string{
+		"4111111111111111": "visa",
+		"5555555555554444": "mastercard",
+		"2223003122003222": "mastercard",
+		"378282246310005":  "amex",
+		"6011111111111117": "discover",
+		"3530111333300000": "jcb",
+		"30569309025904":   "diners",
+		"6200000000000005": "unionpay",
+		"9999999999999999": "",
+	} {
+		if b := CreditCardBrand(number); b != brand {
+			t.Fatalf("expected brand '%s' for %s, got '%s'", brand, number, b)
+		}
+	}
+}
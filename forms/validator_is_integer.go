@@ -16,9 +16,29 @@ package forms
 
 import (
 	"fmt"
-	"strconv"
+	"math"
+	"math/big"
 )
 
+type integerRange struct {
+	Min *big.Int
+	Max *big.Int
+}
+
+// value ranges of the integer types supported by IsInteger
+var integerTypes = map[string]integerRange{
+	"int8":   {big.NewInt(math.MinInt8), big.NewInt(math.MaxInt8)},
+	"int16":  {big.NewInt(math.MinInt16), big.NewInt(math.MaxInt16)},
+	"int32":  {big.NewInt(math.MinInt32), big.NewInt(math.MaxInt32)},
+	"int64":  {big.NewInt(math.MinInt64), big.NewInt(math.MaxInt64)},
+	"int":    {big.NewInt(math.MinInt), big.NewInt(math.MaxInt)},
+	"uint8":  {big.NewInt(0), big.NewInt(math.MaxUint8)},
+	"uint16": {big.NewInt(0), big.NewInt(math.MaxUint16)},
+	"uint32": {big.NewInt(0), big.NewInt(math.MaxUint32)},
+	"uint64": {big.NewInt(0), new(big.Int).SetUint64(math.MaxUint64)},
+	"uint":   {big.NewInt(0), new(big.Int).SetUint64(math.MaxUint)},
+}
+
 var IsIntegerForm = Form{
 	Fields: []Field{
 		{
@@ -46,14 +66,49 @@ var IsIntegerForm = Form{
 			Name: "min",
 			Validators: []Validator{
 				IsOptional{},
-				IsInteger{HasMin: true, Min: 0},
+				IsInteger{},
 			},
 		},
 		{
 			Name: "max",
 			Validators: []Validator{
 				IsOptional{},
-				IsInteger{HasMin: true, Min: 0},
+				IsInteger{},
+			},
+		},
+		{
+			Name: "exclusiveMin",
+			Validators: []Validator{
+				IsOptional{Default: false},
+				IsBoolean{},
+			},
+		},
+		{
+			Name: "exclusiveMax",
+			Validators: []Validator{
+				IsOptional{Default: false},
+				IsBoolean{},
+			},
+		},
+		{
+			Name: "multipleOf",
+			Validators: []Validator{
+				IsOptional{},
+				IsInteger{HasMin: true, Min: 1},
+			},
+		},
+		{
+			Name: "type",
+			Validators: []Validator{
+				IsOptional{},
+				IsIn{Choices: []interface{}{"int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64"}},
+			},
+		},
+		{
+			Name: "locale",
+			Validators: []Validator{
+				IsOptional{},
+				IsString{},
 			},
 		},
 	},
@@ -66,48 +121,146 @@ func MakeIsIntegerValidator(config map[string]interface{}, context *FormDescript
 	} else if err := IsIntegerForm.Coerce(isInteger, params); err != nil {
 		return nil, err
 	}
+	if isInteger.Locale != "" {
+		if _, ok := lookupNumberLocale(isInteger.Locale); !ok {
+			return nil, fmt.Errorf("unsupported locale: '%s'", isInteger.Locale)
+		}
+	}
 	return isInteger, nil
 }
 
+// IsInteger checks for an integer. Min and Max are inclusive bounds unless
+// ExclusiveMin or ExclusiveMax are set. If Type is given (e.g. "int8" or
+// "uint64"), values that do not fit into it are rejected and the value is
+// returned with that type, otherwise it is returned as an int64. If Convert
+// is set, strings are converted as well, using the decimal and grouping
+// separators of Locale if given (e.g. "1.234" for "de").
 type IsInteger struct {
-	Convert bool  `json:"convert,omitempty"`
-	Min     int64 `json:"min,omitempty" coerce:"convert"`
-	Max     int64 `json:"max,omitempty" coerce:"convert"`
-	HasMin  bool  `json:"hasMin,omitempty"`
-	HasMax  bool  `json:"hasMax,omitempty"`
+	Convert      bool   `json:"convert,omitempty"`
+	Min          int64  `json:"min,omitempty" coerce:"convert"`
+	Max          int64  `json:"max,omitempty" coerce:"convert"`
+	HasMin       bool   `json:"hasMin,omitempty"`
+	HasMax       bool   `json:"hasMax,omitempty"`
+	ExclusiveMin bool   `json:"exclusiveMin,omitempty"`
+	ExclusiveMax bool   `json:"exclusiveMax,omitempty"`
+	MultipleOf   int64  `json:"multipleOf,omitempty" coerce:"convert"`
+	Type         string `json:"type,omitempty"`
+	Locale       string `json:"locale,omitempty"`
 }
 
-func (f IsInteger) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
-	var iv int64
+// converts the input to a big integer, which can hold all integer types
+func (f IsInteger) bigInt(input interface{}) (*big.Int, error) {
 	switch v := input.(type) {
 	case int64:
-		iv = v
+		return big.NewInt(v), nil
 	case int:
-		iv = int64(v)
+		return big.NewInt(int64(v)), nil
+	case int8:
+		return big.NewInt(int64(v)), nil
+	case int16:
+		return big.NewInt(int64(v)), nil
+	case int32:
+		return big.NewInt(int64(v)), nil
 	case uint:
-		iv = int64(v)
+		return new(big.Int).SetUint64(uint64(v)), nil
+	case uint8:
+		return big.NewInt(int64(v)), nil
+	case uint16:
+		return big.NewInt(int64(v)), nil
+	case uint32:
+		return big.NewInt(int64(v)), nil
+	case uint64:
+		return new(big.Int).SetUint64(v), nil
+	case float32:
+		return f.bigInt(float64(v))
 	case float64:
-		if float64(int64(v)) != v {
+		if math.IsNaN(v) || math.IsInf(v, 0) || v != math.Trunc(v) {
 			return nil, fmt.Errorf("not an integer")
 		}
-		iv = int64(v)
+		i, _ := big.NewFloat(v).Int(nil)
+		return i, nil
 	case string:
 		if !f.Convert {
 			return nil, fmt.Errorf("not an integer")
 		}
-		i, err := strconv.ParseInt(v, 10, 64)
+		str, err := normalizeNumber(v, f.Locale)
 		if err != nil {
+			return nil, err
+		}
+		i, ok := new(big.Int).SetString(str, 10)
+		if !ok {
 			return nil, fmt.Errorf("not an integer")
 		}
-		iv = i
+		return i, nil
 	default:
 		return nil, fmt.Errorf("not an integer")
 	}
-	if f.HasMin && iv < f.Min {
-		return nil, fmt.Errorf("value must be larger than or equal %d", f.Min)
+}
+
+func (f IsInteger) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+
+	iv, err := f.bigInt(input)
+
+	if err != nil {
+		return nil, err
 	}
-	if f.HasMax && iv > f.Max {
-		return nil, fmt.Errorf("value must be smaller than or equal %d", f.Max)
+
+	if f.HasMin {
+		if c := iv.Cmp(big.NewInt(f.Min)); f.ExclusiveMin && c <= 0 {
+			return nil, fmt.Errorf("value must be larger than %d", f.Min)
+		} else if c < 0 {
+			return nil, fmt.Errorf("value must be larger than or equal %d", f.Min)
+		}
 	}
-	return iv, nil
+
+	if f.HasMax {
+		if c := iv.Cmp(big.NewInt(f.Max)); f.ExclusiveMax && c >= 0 {
+			return nil, fmt.Errorf("value must be smaller than %d", f.Max)
+		} else if c > 0 {
+			return nil, fmt.Errorf("value must be smaller than or equal %d", f.Max)
+		}
+	}
+
+	if f.MultipleOf > 0 && new(big.Int).Rem(iv, big.NewInt(f.MultipleOf)).Sign() != 0 {
+		return nil, fmt.Errorf("value must be a multiple of %d", f.MultipleOf)
+	}
+
+	typ := f.Type
+
+	if typ == "" {
+		typ = "int64"
+	}
+
+	r, ok := integerTypes[typ]
+
+	if !ok {
+		return nil, fmt.Errorf("IsInteger: unknown type '%s'", f.Type)
+	}
+
+	if iv.Cmp(r.Min) < 0 || iv.Cmp(r.Max) > 0 {
+		return nil, fmt.Errorf("value does not fit into %s", typ)
+	}
+
+	switch typ {
+	case "int8":
+		return int8(iv.Int64()), nil
+	case "int16":
+		return int16(iv.Int64()), nil
+	case "int32":
+		return int32(iv.Int64()), nil
+	case "int":
+		return int(iv.Int64()), nil
+	case "uint8":
+		return uint8(iv.Uint64()), nil
+	case "uint16":
+		return uint16(iv.Uint64()), nil
+	case "uint32":
+		return uint32(iv.Uint64()), nil
+	case "uint64":
+		return iv.Uint64(), nil
+	case "uint":
+		return uint(iv.Uint64()), nil
+	}
+
+	return iv.Int64(), nil
 }
@@ -0,0 +1,95 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNumberValidators(t *testing.T) {
+
+	for i, testCase := range []struct {
+		Validator Validator
+		Input     interface{}
+		Output    interface{}
+	}{
+		{IsInteger{}, 42, int64(42)},
+		{IsInteger{HasMin: true, Min: -10}, int64(-10), int64(-10)},
+		{IsInteger{MultipleOf: 5}, 15.0, int64(15)},
+		{IsInteger{Type: "int8"}, 127, int8(127)},
+		{IsInteger{Type: "uint64"}, uint64(math.MaxUint64), uint64(math.MaxUint64)},
+		{IsInteger{Convert: true}, " -12 ", int64(-12)},
+		{IsInteger{Convert: true, Locale: "de"}, "1.234.567", int64(1234567)},
+		{IsInteger{Convert: true, Locale: "en-US"}, "1,234", int64(1234)},
+		{IsInteger{Convert: true, Locale: "fr"}, "1 234", int64(1234)},
+		{IsFloat{}, 1, 1.0},
+		{IsFloat{HasMin: true, Min: -1.5, ExclusiveMin: true}, -1.0, -1.0},
+		{IsFloat{MultipleOf: 0.1}, 0.3, 0.3},
+		{IsFloat{Type: "float32"}, 1.5, float32(1.5)},
+		{IsFloat{Convert: true, Locale: "de"}, "1.234,5", 1234.5},
+		{IsFloat{Convert: true, Locale: "de-CH"}, "1'234.5", 1234.5},
+	} {
+		output, err := testCase.Validator.Validate(testCase.Input, nil)
+		if err != nil {
+			t.Errorf("case %d: %v", i, err)
+		} else if output != testCase.Output {
+			t.Errorf("case %d: expected %v (%T), got %v (%T)", i, testCase.Output, testCase.Output, output, output)
+		}
+	}
+
+	for i, testCase := range []struct {
+		Validator Validator
+		Input     interface{}
+	}{
+		{IsInteger{}, 1.5},
+		{IsInteger{}, math.NaN()},
+		{IsInteger{}, uint64(math.MaxUint64)},
+		{IsInteger{}, "12"},
+		{IsInteger{HasMin: true, Min: 0, ExclusiveMin: true}, 0},
+		{IsInteger{HasMax: true, Max: -1, ExclusiveMax: true}, -1},
+		{IsInteger{MultipleOf: 5}, 12},
+		{IsInteger{Type: "int8"}, 128},
+		{IsInteger{Type: "uint32"}, -1},
+		{IsInteger{Convert: true, Locale: "de"}, "1.23"},
+		{IsInteger{Convert: true, Locale: "de"}, "1,5"},
+		{IsFloat{}, math.Inf(1)},
+		{IsFloat{Convert: true}, "NaN"},
+		{IsFloat{HasMax: true, Max: 1, ExclusiveMax: true}, 1.0},
+		{IsFloat{MultipleOf: 0.25}, 0.3},
+		{IsFloat{Type: "float32"}, 1e39},
+		{IsFloat{Convert: true, Locale: "en"}, "1.234,5"},
+	} {
+		if _, err := testCase.Validator.Validate(testCase.Input, nil); err == nil {
+			t.Errorf("case %d: expected an error", i)
+		}
+	}
+
+	if _, err := MakeIsIntegerValidator(map[string]interface{}{"hasMin": true, "min": -5, "type": "int16"}, nil); err != nil {
+		t.Errorf("negative bounds should be allowed: %v", err)
+	}
+
+	if _, err := MakeIsFloatValidator(map[string]interface{}{"convert": true, "locale": "xx"}, nil); err == nil {
+		t.Errorf("expected an error for an unsupported locale")
+	}
+
+	if _, err := MakeIsIntegerValidator(map[string]interface{}{"multipleOf": 0}, nil); err == nil {
+		t.Errorf("expected an error for multipleOf 0")
+	}
+
+	if _, err := MakeIsFloatValidator(map[string]interface{}{"multipleOf": 0.0}, nil); err == nil {
+		t.Errorf("expected an error for multipleOf 0")
+	}
+}
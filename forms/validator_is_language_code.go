@@ -0,0 +1,71 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"fmt"
+	"strings"
+)
+
+var IsLanguageCodeForm = Form{
+	Fields: []Field{
+		{
+			Name: "format",
+			Validators: []Validator{
+				IsOptional{Default: "any"},
+				IsIn{Choices: []interface{}{"any", "alpha2", "alpha3"}},
+			},
+		},
+		{
+			Name: "convertTo",
+			Validators: []Validator{
+				IsOptional{},
+				IsIn{Choices: []interface{}{"alpha2", "alpha3"}},
+			},
+		},
+	},
+}
+
+func MakeIsLanguageCodeValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+	isLanguageCode := &IsLanguageCode{}
+	if params, err := IsLanguageCodeForm.Validate(config); err != nil {
+		return nil, err
+	} else if err := IsLanguageCodeForm.Coerce(isLanguageCode, params); err != nil {
+		return nil, err
+	}
+	return isLanguageCode, nil
+}
+
+// IsLanguageCode checks for an ISO 639-1 (alpha-2) language code or the
+// ISO 639-2 (alpha-3) code of a language that has an ISO 639-1 code, and
+// returns it in lower case, optionally converted to the format given by
+// ConvertTo. Bibliographic ISO 639-2/B codes (e.g. "ger")
+// are accepted and converted to their terminology equivalents ("deu").
+type IsLanguageCode struct {
+	Format    string `json:"format"`
+	ConvertTo string `json:"convertTo,omitempty"`
+}
+
+func (f IsLanguageCode) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+	str, ok := input.(string)
+	if !ok {
+		return nil, fmt.Errorf("IsLanguageCode: expected a string")
+	}
+	code := strings.ToLower(strings.TrimSpace(str))
+	if terminologyCode, ok := languageBibliographicCodes[code]; ok {
+		code = terminologyCode
+	}
+	return convertCode(code, LanguageCodes, languageCodesAlpha3, f.Format, f.ConvertTo, "language")
+}
@@ -0,0 +1,211 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"fmt"
+	"strings"
+)
+
+var IsLanguageTagForm = Form{
+	Fields: []Field{
+		{
+			Name: "strict",
+			Validators: []Validator{
+				IsOptional{Default: false},
+				IsBoolean{},
+			},
+		},
+	},
+}
+
+func MakeIsLanguageTagValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+	isLanguageTag := &IsLanguageTag{}
+	if params, err := IsLanguageTagForm.Validate(config); err != nil {
+		return nil, err
+	} else if err := IsLanguageTagForm.Coerce(isLanguageTag, params); err != nil {
+		return nil, err
+	}
+	return isLanguageTag, nil
+}
+
+// IsLanguageTag checks for a BCP 47 language tag (e.g. "de-DE" or
+// "zh-Hant-TW") and returns it with canonical casing. Underscores are
+// accepted as separators and replaced by dashes. Two-letter language and
+// region subtags are checked against the embedded ISO tables. Three-letter
+// language subtags (which may come from ISO 639-3) are only checked against
+// them if Strict is set.
+type IsLanguageTag struct {
+	Strict bool `json:"strict"`
+}
+
+func isAlpha(str string) bool {
+	for _, c := range str {
+		if !(c >= 'a' && c <= 'z') {
+			return false
+		}
+	}
+	return true
+}
+
+func isDigits(str string) bool {
+	for _, c := range str {
+		if !(c >= '0' && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlphanumeric(str string) bool {
+	for _, c := range str {
+		if !(c >= 'a' && c <= 'z' || c >= '0' && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func (f IsLanguageTag) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+	str, ok := input.(string)
+	if !ok {
+		return nil, fmt.Errorf("IsLanguageTag: expected a string")
+	}
+
+	subtags := strings.Split(strings.ToLower(strings.Replace(strings.TrimSpace(str), "_", "-", -1)), "-")
+
+	invalid := func(reason string) error {
+		return fmt.Errorf("not a valid language tag: %s", reason)
+	}
+
+	i := 0
+
+	// parses a private use sequence (x-...) starting at the current subtag
+	parsePrivateUse := func() error {
+		i++
+		if i == len(subtags) {
+			return invalid("empty private use sequence")
+		}
+		for ; i < len(subtags); i++ {
+			if len(subtags[i]) < 1 || len(subtags[i]) > 8 || !isAlphanumeric(subtags[i]) {
+				return invalid(fmt.Sprintf("invalid private use subtag '%s'", subtags[i]))
+			}
+		}
+		return nil
+	}
+
+	if subtags[0] == "x" {
+		if err := parsePrivateUse(); err != nil {
+			return nil, err
+		}
+		return strings.Join(subtags, "-"), nil
+	}
+
+	// language
+	language := subtags[0]
+
+	if len(language) < 2 || len(language) > 8 || len(language) == 4 || !isAlpha(language) {
+		return nil, invalid(fmt.Sprintf("invalid language subtag '%s'", language))
+	}
+
+	switch len(language) {
+	case 2:
+		if _, ok := LanguageCodes[language]; !ok {
+			return nil, invalid(fmt.Sprintf("unknown language '%s'", language))
+		}
+	case 3:
+		_, known := languageCodesAlpha3[language]
+		_, bibliographic := languageBibliographicCodes[language]
+		if f.Strict && !known && !bibliographic {
+			return nil, invalid(fmt.Sprintf("unknown language '%s'", language))
+		}
+	}
+
+	i++
+
+	// extended language subtags
+	for j := 0; j < 3 && i < len(subtags) && len(language) <= 3 && len(subtags[i]) == 3 && isAlpha(subtags[i]); j++ {
+		i++
+	}
+
+	// script
+	if i < len(subtags) && len(subtags[i]) == 4 && isAlpha(subtags[i]) {
+		subtags[i] = strings.ToUpper(subtags[i][:1]) + subtags[i][1:]
+		i++
+	}
+
+	// region
+	if i < len(subtags) {
+		region := subtags[i]
+		if len(region) == 2 && isAlpha(region) {
+			region = strings.ToUpper(region)
+			if _, ok := CountryCodes[region]; !ok {
+				return nil, invalid(fmt.Sprintf("unknown region '%s'", region))
+			}
+			subtags[i] = region
+			i++
+		} else if len(region) == 3 && isDigits(region) {
+			i++
+		}
+	}
+
+	// variants
+	for i < len(subtags) {
+		variant := subtags[i]
+		if !isAlphanumeric(variant) {
+			break
+		}
+		if (len(variant) >= 5 && len(variant) <= 8) || (len(variant) == 4 && variant[0] >= '0' && variant[0] <= '9') {
+			i++
+			continue
+		}
+		break
+	}
+
+	// extensions
+	seen := map[string]bool{}
+	for i < len(subtags) && len(subtags[i]) == 1 && subtags[i] != "x" {
+		singleton := subtags[i]
+		if !isAlphanumeric(singleton) {
+			return nil, invalid(fmt.Sprintf("invalid extension '%s'", singleton))
+		}
+		if seen[singleton] {
+			return nil, invalid(fmt.Sprintf("duplicate extension '%s'", singleton))
+		}
+		seen[singleton] = true
+		i++
+		n := 0
+		for i < len(subtags) && len(subtags[i]) >= 2 && len(subtags[i]) <= 8 && isAlphanumeric(subtags[i]) {
+			i++
+			n++
+		}
+		if n == 0 {
+			return nil, invalid(fmt.Sprintf("empty extension '%s'", singleton))
+		}
+	}
+
+	// private use
+	if i < len(subtags) && subtags[i] == "x" {
+		if err := parsePrivateUse(); err != nil {
+			return nil, err
+		}
+	}
+
+	if i < len(subtags) {
+		return nil, invalid(fmt.Sprintf("unexpected subtag '%s'", subtags[i]))
+	}
+
+	return strings.Join(subtags, "-"), nil
+}
@@ -17,6 +17,7 @@ package forms
 import (
 	"fmt"
 	"reflect"
+	"strconv"
 )
 
 var IsListForm = Form{
@@ -38,7 +39,11 @@ var IsListForm = Form{
 }
 
 func (f IsList) Serialize() (map[string]interface{}, error) {
-	if validators, err := SerializeValidators(f.Validators); err != nil {
+	return f.serialize(DefaultRegistry.serializer())
+}
+
+func (f IsList) serialize(s *serializer) (map[string]interface{}, error) {
+	if validators, err := s.serializeValidators(f.Validators); err != nil {
 		return nil, err
 	} else {
 		return map[string]interface{}{
@@ -55,9 +60,9 @@ func MakeIsListValidator(config map[string]interface{}, context *FormDescription
 		return nil, err
 	} else {
 		validators := []Validator{}
-		for _, validatorDescription := range isList.ValidatorDescriptions {
+		for i, validatorDescription := range isList.ValidatorDescriptions {
 			if validator, err := ValidatorFromDescription(validatorDescription, context); err != nil {
-				return nil, err
+				return nil, makeConfigError(fmt.Sprintf("validators[%d]", i), err)
 			} else {
 				validators = append(validators, validator)
 			}
@@ -88,8 +93,18 @@ func (f IsList) validate(input interface{}, values map[string]interface{}, conte
 	vt := reflect.ValueOf(input)
 	if f.Validators != nil {
 		validatedList := make([]interface{}, vt.Len())
+		// warnings for list entries are reported with the index of the entry
+		collector, hasCollector := context[warningsContextKey].(*warningCollector)
+		var field string
+		if hasCollector {
+			field = collector.field
+			defer func() { collector.field = field }()
+		}
 		for i := 0; i < vt.Len(); i++ {
 			entry := vt.Index(i).Interface()
+			if hasCollector {
+				collector.field = joinConfigPath(field, strconv.Itoa(i))
+			}
 			for _, validator := range f.Validators {
 				var err error
 
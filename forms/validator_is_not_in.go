@@ -41,7 +41,7 @@ func MakeIsNotInValidator(config map[string]interface{}, context *FormDescriptio
 }
 
 type IsNotIn struct {
-	Values []interface{} `json:"values"`
+	Values []interface{} `json:"choices"`
 }
 
 func (f IsNotIn) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
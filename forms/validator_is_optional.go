@@ -14,6 +14,10 @@
 
 package forms
 
+import (
+	"fmt"
+)
+
 var IsOptionalForm = Form{
 	Fields: []Field{
 		{
@@ -23,6 +27,14 @@ var IsOptionalForm = Form{
 				CanBeAnything{},
 			},
 		},
+		{
+			Name: "generator",
+			Validators: []Validator{
+				IsOptional{},
+				IsString{},
+				IsValidDefaultGenerator{},
+			},
+		},
 	},
 }
 
@@ -33,21 +45,69 @@ func MakeIsOptionalValidator(config map[string]interface{}, context *FormDescrip
 	} else if err := IsOptionalForm.Coerce(isOptional, params); err != nil {
 		return nil, err
 	}
+	if isOptional.Default != nil && isOptional.Generator != "" {
+		return nil, MakeFormError("invalid config", ConfigErrorCode, map[string]interface{}{
+			"generator": "default and generator cannot both be set",
+		}, nil)
+	}
+	if isOptional.Generator != "" {
+		// we create the generator only once (the description was validated)
+		var err error
+		if isOptional.generatorFunc, err = MakeDefaultGenerator(isOptional.Generator); err != nil {
+			return nil, err
+		}
+	}
 	return isOptional, nil
 }
 
+func (f IsOptional) Serialize() (map[string]interface{}, error) {
+	if f.DefaultGenerator != nil {
+		return nil, fmt.Errorf("IsOptional: validators with a default generator cannot be serialized")
+	}
+	config := map[string]interface{}{}
+	if f.Default != nil {
+		config["default"] = f.Default
+	}
+	if f.Generator != "" {
+		config["generator"] = f.Generator
+	}
+	return config, nil
+}
+
 type IsOptional struct {
 	Default          interface{}        `json:"default,omitempty"`
 	DefaultGenerator func() interface{} `json:"-"`
+	// Generator is the description of a named default generator, e.g.
+	// "uuid4" or "fromField(name)" (see MakeDefaultGenerator)
+	Generator string `json:"generator,omitempty"`
+	// the generator created from Generator by MakeIsOptionalValidator
+	generatorFunc DefaultGeneratorFunc
+}
+
+func (f IsOptional) ValidateWithContext(input interface{}, values map[string]interface{}, context map[string]interface{}) (interface{}, error) {
+	return f.validate(input, values, context)
 }
 
 func (f IsOptional) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+	return f.validate(input, values, nil)
+}
+
+func (f IsOptional) validate(input interface{}, values map[string]interface{}, context map[string]interface{}) (interface{}, error) {
 	if input == nil || input == "" {
 		//if a default value is defined we return that instead
 		if f.Default != nil {
 			return f.Default, nil
 		} else if f.DefaultGenerator != nil {
 			return f.DefaultGenerator(), nil
+		} else if f.generatorFunc != nil {
+			return f.generatorFunc(values, context)
+		} else if f.Generator != "" {
+			// the validator was not created by MakeIsOptionalValidator
+			if generator, err := MakeDefaultGenerator(f.Generator); err != nil {
+				return nil, err
+			} else {
+				return generator(values, context)
+			}
 		}
 		return nil, nil
 	}
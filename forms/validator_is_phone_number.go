@@ -0,0 +1,290 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// PhoneNumberRegion describes the numbering plan of a region: its country
+// calling code, the trunk prefix used when dialing nationally (which is
+// dropped in international format) and the allowed lengths of the national
+// significant number.
+type PhoneNumberRegion struct {
+	CallingCode string
+	TrunkPrefix string
+	MinLength   int
+	MaxLength   int
+}
+
+func (p PhoneNumberRegion) fits(nationalNumber string) bool {
+	return len(nationalNumber) >= p.MinLength && len(nationalNumber) <= p.MaxLength
+}
+
+// basic numbering plans, keyed by ISO 3166-1 alpha-2 code
+var PhoneNumberRegions = map[string]PhoneNumberRegion{
+	"AE": {"971", "0", 8, 9},
+	"AR": {"54", "0", 10, 10},
+	"AT": {"43", "0", 4, 13},
+	"AU": {"61", "0", 9, 9},
+	"BE": {"32", "0", 8, 9},
+	"BG": {"359", "0", 7, 9},
+	"BR": {"55", "0", 10, 11},
+	"CA": {"1", "1", 10, 10},
+	"CH": {"41", "0", 9, 9},
+	"CN": {"86", "0", 8, 11},
+	"CY": {"357", "", 8, 8},
+	"CZ": {"420", "", 9, 9},
+	"DE": {"49", "0", 6, 13},
+	"DK": {"45", "", 8, 8},
+	"EE": {"372", "", 7, 8},
+	"EG": {"20", "0", 9, 10},
+	"ES": {"34", "", 9, 9},
+	"FI": {"358", "0", 5, 12},
+	"FR": {"33", "0", 9, 9},
+	"GB": {"44", "0", 9, 10},
+	"GR": {"30", "", 10, 10},
+	"HK": {"852", "", 8, 8},
+	"HR": {"385", "0", 8, 9},
+	"HU": {"36", "06", 8, 9},
+	"ID": {"62", "0", 9, 12},
+	"IE": {"353", "0", 7, 9},
+	"IL": {"972", "0", 8, 9},
+	"IN": {"91", "0", 10, 10},
+	"IS": {"354", "", 7, 9},
+	"IT": {"39", "", 6, 11},
+	"JP": {"81", "0", 9, 10},
+	"KE": {"254", "0", 9, 9},
+	"KR": {"82", "0", 8, 10},
+	"KZ": {"7", "8", 10, 10},
+	"LT": {"370", "8", 8, 8},
+	"LU": {"352", "", 4, 11},
+	"LV": {"371", "", 8, 8},
+	"MT": {"356", "", 8, 8},
+	"MX": {"52", "", 10, 10},
+	"MY": {"60", "0", 8, 10},
+	"NG": {"234", "0", 8, 10},
+	"NL": {"31", "0", 9, 9},
+	"NO": {"47", "", 8, 8},
+	"NZ": {"64", "0", 8, 10},
+	"PH": {"63", "0", 10, 10},
+	"PK": {"92", "0", 9, 10},
+	"PL": {"48", "", 9, 9},
+	"PT": {"351", "", 9, 9},
+	"RO": {"40", "0", 9, 9},
+	"RU": {"7", "8", 10, 10},
+	"SA": {"966", "0", 9, 9},
+	"SE": {"46", "0", 7, 10},
+	"SG": {"65", "", 8, 8},
+	"SI": {"386", "0", 8, 8},
+	"SK": {"421", "0", 9, 9},
+	"TH": {"66", "0", 8, 9},
+	"TR": {"90", "0", 10, 10},
+	"UA": {"380", "0", 9, 9},
+	"US": {"1", "1", 10, 10},
+	"VN": {"84", "0", 9, 10},
+	"ZA": {"27", "0", 9, 9},
+}
+
+// PhoneNumber is a parsed phone number. Region is empty if the calling code
+// is not contained in PhoneNumberRegions. If several regions share a calling
+// code (e.g. "US" and "CA"), the default region is preferred and the
+// alphabetically first matching region is used otherwise.
+type PhoneNumber struct {
+	CallingCode    string
+	NationalNumber string
+	Region         string
+}
+
+// E164 returns the number in E.164 format, e.g. "+49301234567".
+func (p PhoneNumber) E164() string {
+	return "+" + p.CallingCode + p.NationalNumber
+}
+
+func (p PhoneNumber) String() string {
+	return p.E164()
+}
+
+// returns the regions using the given calling code in alphabetical order
+func phoneNumberRegionsFor(callingCode string) []string {
+	regions := []string{}
+	for region, plan := range PhoneNumberRegions {
+		if plan.CallingCode == callingCode {
+			regions = append(regions, region)
+		}
+	}
+	sort.Strings(regions)
+	return regions
+}
+
+// ParsePhoneNumber parses a phone number in international format (starting
+// with "+" or "00") or, if a default region is given, in national format.
+// Spaces, dashes, dots, slashes and parentheses are ignored, as is a "(0)"
+// trunk prefix in international numbers (e.g. "+49 (0)30 1234567"). Numbers
+// with a calling code from PhoneNumberRegions are checked against the
+// length rules of the region, other numbers only against the overall E.164
+// limit of 15 digits.
+func ParsePhoneNumber(str, defaultRegion string) (PhoneNumber, error) {
+
+	str = strings.TrimSpace(str)
+	international := false
+
+	if strings.HasPrefix(str, "+") {
+		international = true
+		str = strings.Replace(str[1:], "(0)", "", 1)
+	}
+
+	digits := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '\t', '-', '.', '/', '(', ')':
+			return -1
+		}
+		return r
+	}, str)
+
+	if digits == "" {
+		return PhoneNumber{}, fmt.Errorf("empty phone number")
+	}
+
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return PhoneNumber{}, fmt.Errorf("invalid character '%c'", c)
+		}
+	}
+
+	if !international && strings.HasPrefix(digits, "00") {
+		international = true
+		digits = digits[2:]
+	}
+
+	if international {
+		if len(digits) == 0 {
+			return PhoneNumber{}, fmt.Errorf("missing country calling code")
+		}
+		if digits[0] == '0' {
+			return PhoneNumber{}, fmt.Errorf("calling codes cannot start with 0")
+		}
+		for i := 1; i <= 3 && i < len(digits); i++ {
+			callingCode, nationalNumber := digits[:i], digits[i:]
+			regions := phoneNumberRegionsFor(callingCode)
+			if len(regions) == 0 {
+				continue
+			}
+			for _, region := range append([]string{defaultRegion}, regions...) {
+				if plan, ok := PhoneNumberRegions[region]; ok && plan.CallingCode == callingCode && plan.fits(nationalNumber) {
+					return PhoneNumber{callingCode, nationalNumber, region}, nil
+				}
+			}
+			return PhoneNumber{}, fmt.Errorf("invalid length for a number with calling code +%s", callingCode)
+		}
+		// we don't know the numbering plan, so we only check the overall length
+		if len(digits) < 7 || len(digits) > 15 {
+			return PhoneNumber{}, fmt.Errorf("invalid length")
+		}
+		return PhoneNumber{NationalNumber: digits}, nil
+	}
+
+	if defaultRegion == "" {
+		return PhoneNumber{}, fmt.Errorf("missing country calling code")
+	}
+
+	plan, ok := PhoneNumberRegions[defaultRegion]
+
+	if !ok {
+		return PhoneNumber{}, fmt.Errorf("unsupported region: '%s'", defaultRegion)
+	}
+
+	nationalNumber := strings.TrimPrefix(digits, plan.TrunkPrefix)
+
+	if !plan.fits(nationalNumber) {
+		return PhoneNumber{}, fmt.Errorf("invalid length for a number from %s", defaultRegion)
+	}
+
+	return PhoneNumber{plan.CallingCode, nationalNumber, defaultRegion}, nil
+}
+
+var IsPhoneNumberForm = Form{
+	Fields: []Field{
+		{
+			Name: "defaultRegion",
+			Validators: []Validator{
+				IsOptional{},
+				IsString{},
+				IsCountryCode{Format: "alpha2"},
+			},
+		},
+		{
+			Name: "regions",
+			Validators: []Validator{
+				IsOptional{},
+				IsStringList{},
+			},
+		},
+	},
+}
+
+func MakeIsPhoneNumberValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+	isPhoneNumber := &IsPhoneNumber{}
+	if params, err := IsPhoneNumberForm.Validate(config); err != nil {
+		return nil, err
+	} else if err := IsPhoneNumberForm.Coerce(isPhoneNumber, params); err != nil {
+		return nil, err
+	}
+	if isPhoneNumber.DefaultRegion != "" {
+		if _, ok := PhoneNumberRegions[isPhoneNumber.DefaultRegion]; !ok {
+			return nil, fmt.Errorf("unsupported default region: '%s'", isPhoneNumber.DefaultRegion)
+		}
+	}
+	return isPhoneNumber, nil
+}
+
+// IsPhoneNumber checks a phone number and returns it in E.164 format.
+// Numbers without a calling code are interpreted using DefaultRegion. If
+// Regions is given, only numbers from these regions are accepted.
+type IsPhoneNumber struct {
+	DefaultRegion string   `json:"defaultRegion,omitempty"`
+	Regions       []string `json:"regions,omitempty"`
+}
+
+func (f IsPhoneNumber) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+	str, ok := input.(string)
+	if !ok {
+		return nil, fmt.Errorf("IsPhoneNumber: expected a string")
+	}
+
+	number, err := ParsePhoneNumber(str, strings.ToUpper(f.DefaultRegion))
+
+	if err != nil {
+		return nil, fmt.Errorf("not a valid phone number: %v", err)
+	}
+
+	if len(f.Regions) > 0 {
+		found := false
+		for _, region := range f.Regions {
+			// the number may belong to any region that shares its calling code
+			if plan, ok := PhoneNumberRegions[strings.ToUpper(region)]; ok && plan.CallingCode == number.CallingCode && plan.fits(number.NationalNumber) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("phone numbers from this region are not allowed")
+		}
+	}
+
+	return number.E164(), nil
+}
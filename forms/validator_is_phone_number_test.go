@@ -0,0 +1,73 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"testing"
+)
+
+func TestIsPhoneNumber(t *testing.T) {
+
+	validator, err := MakeIsPhoneNumberValidator(map[string]interface{}{"defaultRegion": "de"}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, testCase := range []struct {
+		Input  string
+		Output string
+	}{
+		{"030 1234567", "+49301234567"},
+		{"+49 (30) 1234567", "+49301234567"},
+		{"+49 (0)30 1234567", "+49301234567"},
+		{"0049-30-1234567", "+49301234567"},
+		{"0171/1234567", "+491711234567"},
+		{"+1 (555) 123-4567", "+15551234567"},
+		{"+44 20 7946 0958", "+442079460958"},
+		{"+39 06 1234 5678", "+390612345678"},
+		{"+998 90 123 45 67", "+998901234567"},
+	} {
+		if output, err := validator.Validate(testCase.Input, nil); err != nil {
+			t.Errorf("%s: %v", testCase.Input, err)
+		} else if output != testCase.Output {
+			t.Errorf("%s: expected '%s', got '%v'", testCase.Input, testCase.Output, output)
+		}
+	}
+
+	for _, input := range []string{"", "030 12a4567", "+49 30", "+44 20 7946 0958 123", "+0123456789", "0049 12", "00", "00 - ", "+"} {
+		if _, err := validator.Validate(input, nil); err == nil {
+			t.Errorf("%s: expected an error", input)
+		}
+	}
+
+	if _, err := (IsPhoneNumber{}).Validate("030 1234567", nil); err == nil {
+		t.Errorf("expected an error for a national number without default region")
+	}
+
+	usOnly := IsPhoneNumber{Regions: []string{"US"}}
+
+	if _, err := usOnly.Validate("+1 555 123 4567", nil); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := usOnly.Validate("+49 30 1234567", nil); err == nil {
+		t.Errorf("expected an error for a number from a region that is not allowed")
+	}
+
+	if _, err := MakeIsPhoneNumberValidator(map[string]interface{}{"defaultRegion": "XX"}, nil); err == nil {
+		t.Errorf("expected an error for an unknown default region")
+	}
+}
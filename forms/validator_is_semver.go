@@ -0,0 +1,284 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Semver is a semantic version as defined by https://semver.org (2.0.0)
+type Semver struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease []string
+	Build      []string
+}
+
+func ParseSemver(str string) (Semver, error) {
+	v := Semver{}
+
+	if i := strings.Index(str, "+"); i >= 0 {
+		v.Build = strings.Split(str[i+1:], ".")
+		for _, identifier := range v.Build {
+			if !isSemverIdentifier(identifier) {
+				return v, fmt.Errorf("invalid build metadata: '%s'", str[i+1:])
+			}
+		}
+		str = str[:i]
+	}
+
+	if i := strings.Index(str, "-"); i >= 0 {
+		v.Prerelease = strings.Split(str[i+1:], ".")
+		for _, identifier := range v.Prerelease {
+			if !isSemverIdentifier(identifier) || (isSemverNumber(identifier) && !isSemverNumeric(identifier)) {
+				return v, fmt.Errorf("invalid prerelease version: '%s'", str[i+1:])
+			}
+		}
+		str = str[:i]
+	}
+
+	components := strings.Split(str, ".")
+
+	if len(components) != 3 {
+		return v, fmt.Errorf("expected a version of the form major.minor.patch")
+	}
+
+	numbers := make([]uint64, 3)
+
+	for i, component := range components {
+		if !isSemverNumeric(component) {
+			return v, fmt.Errorf("invalid version number: '%s'", component)
+		}
+		n, err := strconv.ParseUint(component, 10, 64)
+		if err != nil {
+			return v, fmt.Errorf("invalid version number: '%s'", component)
+		}
+		numbers[i] = n
+	}
+
+	v.Major, v.Minor, v.Patch = numbers[0], numbers[1], numbers[2]
+
+	return v, nil
+}
+
+// checks for a non-empty identifier made up of [0-9A-Za-z-]
+func isSemverIdentifier(str string) bool {
+	if str == "" {
+		return false
+	}
+	for _, c := range str {
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// checks if a string consists only of digits
+func isSemverNumber(str string) bool {
+	if str == "" {
+		return false
+	}
+	for _, c := range str {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// checks for a number without leading zeroes
+func isSemverNumeric(str string) bool {
+	return isSemverNumber(str) && (str == "0" || str[0] != '0')
+}
+
+func (v Semver) String() string {
+	str := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.Prerelease) > 0 {
+		str += "-" + strings.Join(v.Prerelease, ".")
+	}
+	if len(v.Build) > 0 {
+		str += "+" + strings.Join(v.Build, ".")
+	}
+	return str
+}
+
+func (v Semver) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(v.String())), nil
+}
+
+// Compare returns -1, 0 or 1 depending on whether v has a lower, equal or
+// higher precedence than w. Build metadata is ignored.
+func (v Semver) Compare(w Semver) int {
+	compareNumbers := func(a, b uint64) int {
+		if a < b {
+			return -1
+		} else if a > b {
+			return 1
+		}
+		return 0
+	}
+
+	if c := compareNumbers(v.Major, w.Major); c != 0 {
+		return c
+	}
+	if c := compareNumbers(v.Minor, w.Minor); c != 0 {
+		return c
+	}
+	if c := compareNumbers(v.Patch, w.Patch); c != 0 {
+		return c
+	}
+
+	// a version without prerelease has a higher precedence
+	if len(v.Prerelease) == 0 && len(w.Prerelease) == 0 {
+		return 0
+	} else if len(v.Prerelease) == 0 {
+		return 1
+	} else if len(w.Prerelease) == 0 {
+		return -1
+	}
+
+	for i := 0; i < len(v.Prerelease) && i < len(w.Prerelease); i++ {
+		a, b := v.Prerelease[i], w.Prerelease[i]
+		aNumeric, bNumeric := isSemverNumber(a), isSemverNumber(b)
+		switch {
+		case aNumeric && bNumeric:
+			an, _ := strconv.ParseUint(a, 10, 64)
+			bn, _ := strconv.ParseUint(b, 10, 64)
+			if c := compareNumbers(an, bn); c != 0 {
+				return c
+			}
+		case aNumeric:
+			// numeric identifiers have a lower precedence
+			return -1
+		case bNumeric:
+			return 1
+		default:
+			if c := strings.Compare(a, b); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return compareNumbers(uint64(len(v.Prerelease)), uint64(len(w.Prerelease)))
+}
+
+var IsSemverForm = Form{
+	Fields: []Field{
+		{
+			Name: "min",
+			Validators: []Validator{
+				IsOptional{},
+				IsString{},
+				IsSemver{Raw: true},
+			},
+		},
+		{
+			Name: "max",
+			Validators: []Validator{
+				IsOptional{},
+				IsString{},
+				IsSemver{Raw: true},
+			},
+		},
+		{
+			Name: "excludePrerelease",
+			Validators: []Validator{
+				IsOptional{Default: false},
+				IsBoolean{},
+			},
+		},
+		{
+			Name: "allowPrefix",
+			Validators: []Validator{
+				IsOptional{Default: false},
+				IsBoolean{},
+			},
+		},
+		{
+			Name: "raw",
+			Validators: []Validator{
+				IsOptional{Default: false},
+				IsBoolean{},
+			},
+		},
+	},
+}
+
+func MakeIsSemverValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+	isSemver := &IsSemver{}
+	if params, err := IsSemverForm.Validate(config); err != nil {
+		return nil, err
+	} else if err := IsSemverForm.Coerce(isSemver, params); err != nil {
+		return nil, err
+	}
+	return isSemver, nil
+}
+
+// IsSemver parses a semantic version and returns it as a Semver value (or as
+// a normalized string if Raw is set). Min and Max are inclusive bounds.
+type IsSemver struct {
+	Min               string `json:"min,omitempty"`
+	Max               string `json:"max,omitempty"`
+	ExcludePrerelease bool   `json:"excludePrerelease"`
+	AllowPrefix       bool   `json:"allowPrefix"`
+	Raw               bool   `json:"raw"`
+}
+
+func (f IsSemver) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+	str, ok := input.(string)
+	if !ok {
+		return nil, fmt.Errorf("IsSemver: expected a string")
+	}
+
+	if f.AllowPrefix {
+		str = strings.TrimPrefix(str, "v")
+	}
+
+	v, err := ParseSemver(str)
+
+	if err != nil {
+		return nil, fmt.Errorf("not a valid semantic version: %v", err)
+	}
+
+	if f.ExcludePrerelease && len(v.Prerelease) > 0 {
+		return nil, fmt.Errorf("prerelease versions are not allowed")
+	}
+
+	if f.Min != "" {
+		if min, err := ParseSemver(f.Min); err != nil {
+			return nil, fmt.Errorf("invalid minimum version: %v", err)
+		} else if v.Compare(min) < 0 {
+			return nil, fmt.Errorf("version must be at least %s", f.Min)
+		}
+	}
+
+	if f.Max != "" {
+		if max, err := ParseSemver(f.Max); err != nil {
+			return nil, fmt.Errorf("invalid maximum version: %v", err)
+		} else if v.Compare(max) > 0 {
+			return nil, fmt.Errorf("version must be at most %s", f.Max)
+		}
+	}
+
+	if f.Raw {
+		return v.String(), nil
+	}
+
+	return v, nil
+}
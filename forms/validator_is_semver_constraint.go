@@ -0,0 +1,310 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type semverComparator struct {
+	Operator string
+	Version  Semver
+}
+
+func (c semverComparator) check(v Semver) bool {
+	cmp := v.Compare(c.Version)
+	switch c.Operator {
+	case "=":
+		return cmp == 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+	return false
+}
+
+// SemverConstraint is a set of version ranges, e.g. ">=1.2, <2.0 || ^3.1".
+// Comparators separated by commas or whitespace must all match, ranges
+// separated by "||" are alternatives. Besides the comparison operators
+// (=, !=, >, >=, <, <=) we support partial versions ("1.2", "1.x"), tilde
+// ranges ("~1.2.3") and caret ranges ("^1.2.3") with the usual semantics.
+type SemverConstraint struct {
+	source string
+	ranges [][]semverComparator
+}
+
+func (c SemverConstraint) String() string {
+	return c.source
+}
+
+func (c SemverConstraint) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(c.source)), nil
+}
+
+// Check returns true if the given version satisfies the constraint.
+func (c SemverConstraint) Check(v Semver) bool {
+	for _, comparators := range c.ranges {
+		matches := true
+		for _, comparator := range comparators {
+			if !comparator.check(v) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+func ParseSemverConstraint(str string) (SemverConstraint, error) {
+	constraint := SemverConstraint{source: strings.TrimSpace(str)}
+
+	for _, rangeStr := range strings.Split(str, "||") {
+
+		tokens := strings.Fields(strings.Replace(rangeStr, ",", " ", -1))
+
+		if len(tokens) == 0 {
+			return constraint, fmt.Errorf("empty version range")
+		}
+
+		comparators := []semverComparator{}
+
+		for i := 0; i < len(tokens); i++ {
+			token := tokens[i]
+
+			// operators may be separated from their version by whitespace
+			if strings.Trim(token, "=!<>~^") == "" && i+1 < len(tokens) {
+				i++
+				token += tokens[i]
+			}
+
+			if tokenComparators, err := parseSemverComparator(token); err != nil {
+				return constraint, err
+			} else {
+				comparators = append(comparators, tokenComparators...)
+			}
+		}
+
+		constraint.ranges = append(constraint.ranges, comparators)
+	}
+
+	return constraint, nil
+}
+
+// parses a possibly partial version like "1", "1.2", "1.x" or "*" and
+// returns the version (with missing components set to zero) as well as the
+// number of components that were given.
+func parsePartialSemver(str string) (Semver, int, error) {
+
+	if str == "*" || str == "x" || str == "X" {
+		return Semver{}, 0, nil
+	}
+
+	prefix := str
+	suffix := ""
+
+	if i := strings.IndexAny(str, "-+"); i >= 0 {
+		prefix, suffix = str[:i], str[i:]
+	}
+
+	components := strings.Split(prefix, ".")
+
+	if len(components) > 3 {
+		return Semver{}, 0, fmt.Errorf("invalid version: '%s'", str)
+	}
+
+	n := 0
+
+	for _, component := range components {
+		if component == "*" || component == "x" || component == "X" {
+			break
+		}
+		n++
+	}
+
+	for i := n; i < len(components); i++ {
+		if c := components[i]; c != "*" && c != "x" && c != "X" {
+			return Semver{}, 0, fmt.Errorf("invalid version: '%s'", str)
+		}
+	}
+
+	if n < 3 && suffix != "" {
+		return Semver{}, 0, fmt.Errorf("invalid version: '%s'", str)
+	}
+
+	full := make([]string, 3)
+
+	for i := 0; i < 3; i++ {
+		if i < n {
+			full[i] = components[i]
+		} else {
+			full[i] = "0"
+		}
+	}
+
+	v, err := ParseSemver(strings.Join(full, ".") + suffix)
+
+	if err != nil {
+		return v, 0, err
+	}
+
+	return v, n, nil
+}
+
+// returns the smallest version that is larger than all versions matching
+// the first n components of v
+func semverUpperBound(v Semver, n int) Semver {
+	switch n {
+	case 1:
+		return Semver{Major: v.Major + 1, Prerelease: []string{"0"}}
+	case 2:
+		return Semver{Major: v.Major, Minor: v.Minor + 1, Prerelease: []string{"0"}}
+	default:
+		return Semver{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1, Prerelease: []string{"0"}}
+	}
+}
+
+func parseSemverComparator(token string) ([]semverComparator, error) {
+
+	operator := ""
+
+	for _, op := range []string{">=", "<=", "!=", "==", ">", "<", "=", "~", "^"} {
+		if strings.HasPrefix(token, op) {
+			operator = op
+			break
+		}
+	}
+
+	versionStr := strings.TrimPrefix(token[len(operator):], "v")
+
+	v, n, err := parsePartialSemver(versionStr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// "*" matches everything
+	if n == 0 {
+		switch operator {
+		case "", "=", "==", ">=", "<=", "~", "^":
+			return []semverComparator{{">=", Semver{}}}, nil
+		default:
+			return nil, fmt.Errorf("invalid version range: '%s'", token)
+		}
+	}
+
+	switch operator {
+	case "", "=", "==":
+		if n == 3 {
+			return []semverComparator{{"=", v}}, nil
+		}
+		return []semverComparator{{">=", v}, {"<", semverUpperBound(v, n)}}, nil
+	case "!=":
+		if n == 3 {
+			return []semverComparator{{"!=", v}}, nil
+		}
+		return nil, fmt.Errorf("'!=' requires a full version: '%s'", token)
+	case ">=":
+		return []semverComparator{{">=", v}}, nil
+	case "<":
+		return []semverComparator{{"<", v}}, nil
+	case ">":
+		if n == 3 {
+			return []semverComparator{{">", v}}, nil
+		}
+		return []semverComparator{{">=", semverUpperBound(v, n)}}, nil
+	case "<=":
+		if n == 3 {
+			return []semverComparator{{"<=", v}}, nil
+		}
+		return []semverComparator{{"<", semverUpperBound(v, n)}}, nil
+	case "~":
+		// ~1.2.3 := >=1.2.3 <1.3.0, ~1 := >=1.0.0 <2.0.0
+		if n == 1 {
+			return []semverComparator{{">=", v}, {"<", semverUpperBound(v, 1)}}, nil
+		}
+		return []semverComparator{{">=", v}, {"<", semverUpperBound(v, 2)}}, nil
+	case "^":
+		// ^1.2.3 := >=1.2.3 <2.0.0, ^0.2.3 := >=0.2.3 <0.3.0, ^0.0.3 := >=0.0.3 <0.0.4
+		switch {
+		case v.Major != 0 || n == 1:
+			return []semverComparator{{">=", v}, {"<", semverUpperBound(v, 1)}}, nil
+		case v.Minor != 0 || n == 2:
+			return []semverComparator{{">=", v}, {"<", semverUpperBound(v, 2)}}, nil
+		default:
+			return []semverComparator{{">=", v}, {"<", semverUpperBound(v, 3)}}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid version range: '%s'", token)
+}
+
+var IsSemverConstraintForm = Form{
+	Fields: []Field{
+		{
+			Name: "raw",
+			Validators: []Validator{
+				IsOptional{Default: false},
+				IsBoolean{},
+			},
+		},
+	},
+}
+
+func MakeIsSemverConstraintValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+	isSemverConstraint := &IsSemverConstraint{}
+	if params, err := IsSemverConstraintForm.Validate(config); err != nil {
+		return nil, err
+	} else if err := IsSemverConstraintForm.Coerce(isSemverConstraint, params); err != nil {
+		return nil, err
+	}
+	return isSemverConstraint, nil
+}
+
+// IsSemverConstraint parses a version constraint and returns it as a
+// SemverConstraint value (or as the trimmed input string if Raw is set).
+type IsSemverConstraint struct {
+	Raw bool `json:"raw"`
+}
+
+func (f IsSemverConstraint) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+	str, ok := input.(string)
+	if !ok {
+		return nil, fmt.Errorf("IsSemverConstraint: expected a string")
+	}
+
+	constraint, err := ParseSemverConstraint(str)
+
+	if err != nil {
+		return nil, fmt.Errorf("not a valid version constraint: %v", err)
+	}
+
+	if f.Raw {
+		return constraint.String(), nil
+	}
+
+	return constraint, nil
+}
@@ -0,0 +1,152 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"testing"
+)
+
+func TestSemverPrecedence(t *testing.T) {
+	// taken from the SemVer 2.0.0 specification
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0",
+		"1.0.1",
+		"1.1.0",
+		"2.0.0",
+	}
+
+	for i := 1; i < len(ordered); i++ {
+		a, err := ParseSemver(ordered[i-1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := ParseSemver(ordered[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a.Compare(b) != -1 || b.Compare(a) != 1 {
+			t.Fatalf("expected %s < %s", a, b)
+		}
+	}
+
+	a, _ := ParseSemver("1.0.0+build.1")
+	b, _ := ParseSemver("1.0.0+build.2")
+
+	if a.Compare(b) != 0 {
+		t.Fatalf("build metadata should be ignored")
+	}
+
+	for _, invalid := range []string{"1.0", "01.0.0", "1.0.0-01", "1.0.0-", "1.0.0+", "v1.0.0", "1.0.0-alpha..1"} {
+		if _, err := ParseSemver(invalid); err == nil {
+			t.Fatalf("expected an error for '%s'", invalid)
+		}
+	}
+}
+
+func TestSemverConstraint(t *testing.T) {
+	for constraint, cases := range map[string]map[string]bool{
+		">=1.2, <2.0": {"1.2.0": true, "1.9.9": true, "1.1.9": false, "2.0.0": false},
+		">= 1.2 < 2":  {"1.5.0": true, "2.0.0": false},
+		"~1.2.3":      {"1.2.3": true, "1.2.9": true, "1.3.0": false, "1.2.2": false},
+		"^1.2.3":      {"1.2.3": true, "1.9.0": true, "2.0.0": false, "2.0.0-rc.1": false},
+		"^0.2.3":      {"0.2.5": true, "0.3.0": false},
+		"1.2.x":       {"1.2.0": true, "1.2.7": true, "1.3.0": false},
+		"<1.0 || >=3": {"0.9.0": true, "3.1.0": true, "2.0.0": false},
+		"!=1.0.0":     {"1.0.0": false, "1.0.1": true},
+		"*":           {"0.0.1": true, "12.3.4": true},
+	} {
+		c, err := ParseSemverConstraint(constraint)
+		if err != nil {
+			t.Fatalf("%s: %v", constraint, err)
+		}
+		for version, expected := range cases {
+			v, err := ParseSemver(version)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c.Check(v) != expected {
+				t.Fatalf("%s: expected %t for %s", constraint, expected, version)
+			}
+		}
+	}
+
+	for _, invalid := range []string{"", ">=", "1.2.3.4", "!=1.2", ">=1.a"} {
+		if _, err := ParseSemverConstraint(invalid); err == nil {
+			t.Fatalf("expected an error for '%s'", invalid)
+		}
+	}
+}
+
+func TestIsSemverFromConfig(t *testing.T) {
+	config := map[string]interface{}{
+		"fields": []map[string]interface{}{
+			{
+				"name": "version",
+				"validators": []map[string]interface{}{
+					{
+						"type": "IsSemver",
+						"config": map[string]interface{}{
+							"min": "1.0.0",
+							"max": "2.0.0",
+						},
+					},
+				},
+			},
+			{
+				"name": "requires",
+				"validators": []map[string]interface{}{
+					{
+						"type": "IsSemverConstraint",
+					},
+				},
+			},
+		},
+	}
+	context := &FormDescriptionContext{
+		Validators: Validators,
+	}
+	form, err := FromConfig(config, context)
+	if err != nil {
+		t.Fatal(err)
+	}
+	params, err := form.Validate(map[string]interface{}{"version": "1.4.2", "requires": ">=1.2, <2.0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	version, ok := params["version"].(Semver)
+	if !ok {
+		t.Fatalf("expected a Semver value")
+	}
+	constraint, ok := params["requires"].(SemverConstraint)
+	if !ok {
+		t.Fatalf("expected a SemverConstraint value")
+	}
+	if !constraint.Check(version) {
+		t.Fatalf("expected the version to satisfy the constraint")
+	}
+	if _, err := form.Validate(map[string]interface{}{"version": "2.1.0", "requires": "*"}); err == nil {
+		t.Fatalf("expected an error")
+	}
+	if _, err := MakeIsSemverValidator(map[string]interface{}{"min": "1.0"}, context); err == nil {
+		t.Fatalf("expected an invalid minimum version to be rejected")
+	}
+}
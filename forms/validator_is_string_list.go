@@ -37,7 +37,11 @@ var IsStringListForm = Form{
 }
 
 func (f IsStringList) Serialize() (map[string]interface{}, error) {
-	if validators, err := SerializeValidators(f.Validators); err != nil {
+	return f.serialize(DefaultRegistry.serializer())
+}
+
+func (f IsStringList) serialize(s *serializer) (map[string]interface{}, error) {
+	if validators, err := s.serializeValidators(f.Validators); err != nil {
 		return nil, err
 	} else {
 		return map[string]interface{}{
@@ -54,9 +58,9 @@ func MakeIsStringListValidator(config map[string]interface{}, context *FormDescr
 		return nil, err
 	} else {
 		validators := []Validator{}
-		for _, validatorDescription := range isStringList.ValidatorDescriptions {
+		for i, validatorDescription := range isStringList.ValidatorDescriptions {
 			if validator, err := ValidatorFromDescription(validatorDescription, context); err != nil {
-				return nil, err
+				return nil, makeConfigError(fmt.Sprintf("validators[%d]", i), err)
 			} else {
 				validators = append(validators, validator)
 			}
@@ -24,9 +24,7 @@ var IsStringMapForm = Form{
 			Name: "form",
 			Validators: []Validator{
 				IsOptional{},
-				IsStringMap{
-					Form: &FormForm,
-				},
+				IsFormDescription{},
 			},
 		},
 	},
@@ -36,20 +34,67 @@ func MakeIsStringMapValidator(config map[string]interface{}, context *FormDescri
 	isStringMap := &IsStringMap{}
 	if params, err := IsStringMapForm.Validate(config); err != nil {
 		return nil, err
-	} else if err := IsStringMapForm.Coerce(isStringMap, params); err != nil {
+	} else if isStringMap.Form, isStringMap.Ref, err = formFromParams(params["form"], context); err != nil {
 		return nil, err
-	} else {
-		if isStringMap.Form != nil {
-			if err := isStringMap.Form.Initialize(context); err != nil {
-				return nil, err
-			}
-		}
 	}
 	return isStringMap, nil
 }
 
+// creates a form from the validated "form" parameter of a validator config,
+// which is either a form description or a reference to a named form (in
+// which case the name of the form is returned as well)
+func formFromParams(params interface{}, context *FormDescriptionContext) (*Form, string, error) {
+	formParams, ok := params.(map[string]interface{})
+	if !ok {
+		return nil, "", nil
+	}
+	if ref, ok := formParams["$ref"].(string); ok {
+		// named forms are shared, which makes recursive forms possible
+		if form, err := context.form(ref); err != nil {
+			return nil, "", makeConfigError("form.$ref", err)
+		} else {
+			return form, ref, nil
+		}
+	}
+	form := &Form{}
+	if err := form.fromParams(formParams, context); err != nil {
+		return nil, "", makeConfigError("form", err)
+	}
+	return form, "", nil
+}
+
+// serializes a form or, if ref is given, a reference to a named form
+func serializeFormOrRef(form *Form, ref string, s *serializer) (interface{}, error) {
+	if ref != "" {
+		// we serialize references as such to avoid cycles
+		return map[string]interface{}{"$ref": ref}, nil
+	}
+	return s.serializeForm(form)
+}
+
+func (f IsStringMap) Serialize() (map[string]interface{}, error) {
+	return f.serialize(DefaultRegistry.serializer())
+}
+
+func (f IsStringMap) serialize(s *serializer) (map[string]interface{}, error) {
+	if f.Coerce != nil {
+		return nil, fmt.Errorf("IsStringMap: validators with a coerce target cannot be serialized")
+	}
+	config := map[string]interface{}{}
+	if f.Form != nil {
+		if form, err := serializeFormOrRef(f.Form, f.Ref, s); err != nil {
+			return nil, err
+		} else {
+			config["form"] = form
+		}
+	}
+	return config, nil
+}
+
 type IsStringMap struct {
-	Form   *Form       `json:"form,omitempty"`
+	Form *Form `json:"form,omitempty"`
+	// Ref is the name of the named form that Form refers to (if any)
+	Ref    string      `json:"-"`
 	Coerce interface{} `json:"-"`
 }
 
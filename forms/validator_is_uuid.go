@@ -20,6 +20,74 @@ import (
 	"strings"
 )
 
+// UUID is a binary UUID as defined in RFC 9562 (formerly RFC 4122).
+type UUID [16]byte
+
+// ParseUUID parses a UUID in canonical form (e.g.
+// "f81d4fae-7dec-11d0-a765-00a0c91e6bf6", case-insensitive). If compact is
+// set, the form without dashes is accepted as well. Version and variant are
+// not checked.
+func ParseUUID(str string, compact bool) (UUID, error) {
+	var uuid UUID
+
+	switch len(str) {
+	case 36:
+		for _, i := range []int{8, 13, 18, 23} {
+			if str[i] != '-' {
+				return uuid, fmt.Errorf("invalid UUID format")
+			}
+		}
+		str = str[0:8] + str[9:13] + str[14:18] + str[19:23] + str[24:]
+	case 32:
+		if !compact {
+			return uuid, fmt.Errorf("invalid UUID format")
+		}
+	default:
+		return uuid, fmt.Errorf("invalid UUID length")
+	}
+
+	if _, err := hex.Decode(uuid[:], []byte(str)); err != nil {
+		return uuid, fmt.Errorf("invalid UUID format")
+	}
+
+	return uuid, nil
+}
+
+// Version returns the version of the UUID (the high nibble of byte 6).
+func (u UUID) Version() int {
+	return int(u[6] >> 4)
+}
+
+// IsRFCVariant checks whether the UUID has the variant defined by RFC 9562
+// (the two most significant bits of byte 8 are 10).
+func (u UUID) IsRFCVariant() bool {
+	return u[8]&0xc0 == 0x80
+}
+
+// IsNil checks whether all bits of the UUID are zero.
+func (u UUID) IsNil() bool {
+	return u == UUID{}
+}
+
+// String returns the UUID in canonical form (lower case with dashes).
+func (u UUID) String() string {
+	str := hex.EncodeToString(u[:])
+	return str[0:8] + "-" + str[8:12] + "-" + str[12:16] + "-" + str[16:20] + "-" + str[20:]
+}
+
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+func (u *UUID) UnmarshalText(text []byte) error {
+	uuid, err := ParseUUID(string(text), false)
+	if err != nil {
+		return err
+	}
+	*u = uuid
+	return nil
+}
+
 var IsUUIDForm = Form{
 	Fields: []Field{
 		{
@@ -29,6 +97,38 @@ var IsUUIDForm = Form{
 				IsBoolean{},
 			},
 		},
+		{
+			Name: "native",
+			Validators: []Validator{
+				IsOptional{Default: false},
+				IsBoolean{},
+			},
+		},
+		{
+			Name: "versions",
+			Validators: []Validator{
+				IsOptional{},
+				IsList{
+					Validators: []Validator{
+						IsInteger{HasMin: true, Min: 1, HasMax: true, Max: 8},
+					},
+				},
+			},
+		},
+		{
+			Name: "allowCompact",
+			Validators: []Validator{
+				IsOptional{Default: false},
+				IsBoolean{},
+			},
+		},
+		{
+			Name: "allowNil",
+			Validators: []Validator{
+				IsOptional{Default: false},
+				IsBoolean{},
+			},
+		},
 	},
 }
 
@@ -42,25 +142,71 @@ func MakeIsUUIDValidator(config map[string]interface{}, context *FormDescription
 	return isUUID, nil
 }
 
+// IsUUID checks for a UUID in canonical form (or without dashes if
+// AllowCompact is set) with the RFC 9562 variant and a version between 1 and
+// 8 (or one of Versions if given). The nil UUID is only accepted if AllowNil
+// is set. The UUID is returned in canonical form (lower case with dashes),
+// as a UUID value if Native is set or as a byte slice if ConvertToBinary is
+// set.
 type IsUUID struct {
-	ConvertToBinary bool `json:"convertToBinary"`
+	ConvertToBinary bool    `json:"convertToBinary"`
+	Native          bool    `json:"native"`
+	Versions        []int64 `json:"versions,omitempty"`
+	AllowCompact    bool    `json:"allowCompact"`
+	AllowNil        bool    `json:"allowNil"`
 }
 
 func (f IsUUID) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
-	uuidStr, ok := input.(string)
-	if !ok {
+	var uuid UUID
+
+	switch v := input.(type) {
+	case string:
+		var err error
+		if uuid, err = ParseUUID(strings.TrimSpace(v), f.AllowCompact); err != nil {
+			return nil, fmt.Errorf("not a valid UUID: %v", err)
+		}
+	case UUID:
+		uuid = v
+	default:
 		return nil, fmt.Errorf("not a valid UUID")
 	}
-	rawUUIDStr := strings.Replace(uuidStr, "-", "", -1)
-	bStr, err := hex.DecodeString(rawUUIDStr)
-	if err != nil {
-		return nil, fmt.Errorf("not a valid UUID")
+
+	if uuid.IsNil() {
+		if !f.AllowNil {
+			return nil, fmt.Errorf("the nil UUID is not allowed")
+		}
+	} else {
+		if !uuid.IsRFCVariant() {
+			return nil, fmt.Errorf("not a valid UUID: unsupported variant")
+		}
+
+		version := uuid.Version()
+
+		if version < 1 || version > 8 {
+			return nil, fmt.Errorf("not a valid UUID: unsupported version %d", version)
+		}
+
+		if len(f.Versions) > 0 {
+			found := false
+			for _, allowedVersion := range f.Versions {
+				if int64(version) == allowedVersion {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("UUID version %d is not allowed", version)
+			}
+		}
 	}
-	if len(bStr) != 16 {
-		return nil, fmt.Errorf("not a valid UUID")
+
+	if f.Native {
+		return uuid, nil
 	}
+
 	if f.ConvertToBinary {
-		return bStr, nil
+		return uuid[:], nil
 	}
-	return uuidStr, nil
+
+	return uuid.String(), nil
 }
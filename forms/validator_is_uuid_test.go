@@ -0,0 +1,113 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestIsUUID(t *testing.T) {
+
+	validator, err := MakeIsUUIDValidator(map[string]interface{}{"versions": []interface{}{4, 7}}, nil)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, testCase := range []struct {
+		Input  string
+		Output string
+	}{
+		{"F81D4FAE-7DEC-41D0-A765-00A0C91E6BF6", "f81d4fae-7dec-41d0-a765-00a0c91e6bf6"},
+		{"018f3c4e-8b7a-7c3d-9f21-5a6b7c8d9e0f", "018f3c4e-8b7a-7c3d-9f21-5a6b7c8d9e0f"},
+	} {
+		if output, err := validator.Validate(testCase.Input, nil); err != nil {
+			t.Errorf("%s: %v", testCase.Input, err)
+		} else if output != testCase.Output {
+			t.Errorf("%s: expected '%s', got '%v'", testCase.Input, testCase.Output, output)
+		}
+	}
+
+	for _, input := range []string{
+		// version 1
+		"f81d4fae-7dec-11d0-a765-00a0c91e6bf6",
+		// invalid variant
+		"f81d4fae-7dec-41d0-c765-00a0c91e6bf6",
+		// misplaced dashes
+		"f81d4fae7-dec-41d0-a765-00a0c91e6bf6",
+		// compact
+		"f81d4fae7dec41d0a76500a0c91e6bf6",
+		"00000000-0000-0000-0000-000000000000",
+		"g81d4fae-7dec-41d0-a765-00a0c91e6bf6",
+	} {
+		if _, err := validator.Validate(input, nil); err == nil {
+			t.Errorf("%s: expected an error", input)
+		}
+	}
+
+	if _, err := (IsUUID{AllowCompact: true}).Validate("f81d4fae7dec41d0a76500a0c91e6bf6", nil); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := (IsUUID{AllowNil: true}).Validate("00000000-0000-0000-0000-000000000000", nil); err != nil {
+		t.Error(err)
+	}
+
+	if output, err := (IsUUID{ConvertToBinary: true}).Validate("f81d4fae-7dec-41d0-a765-00a0c91e6bf6", nil); err != nil {
+		t.Error(err)
+	} else if b, ok := output.([]byte); !ok || !bytes.Equal(b[:2], []byte{0xf8, 0x1d}) {
+		t.Errorf("expected a byte slice, got %v", output)
+	}
+}
+
+func TestCoerceUUID(t *testing.T) {
+
+	type Target struct {
+		ID      UUID  `json:"id"`
+		Parent  *UUID `json:"parent"`
+		Another UUID  `json:"another"`
+	}
+
+	form := Form{
+		Fields: []Field{
+			{Name: "id", Validators: []Validator{IsUUID{Native: true}}},
+			{Name: "parent", Validators: []Validator{IsUUID{Native: true}}},
+			{Name: "another", Validators: []Validator{IsUUID{Native: true}}},
+		},
+	}
+
+	str := "f81d4fae-7dec-41d0-a765-00a0c91e6bf6"
+
+	params, err := form.Validate(map[string]interface{}{"id": str, "parent": str, "another": str})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	target := &Target{}
+
+	if err := form.Coerce(target, params); err != nil {
+		t.Fatal(err)
+	}
+
+	if target.ID.String() != str || target.Parent == nil || target.Parent.String() != str || target.Another != target.ID {
+		t.Errorf("unexpected result: %v", target)
+	}
+
+	if target.ID.Version() != 4 {
+		t.Errorf("expected version 4, got %d", target.ID.Version())
+	}
+}
@@ -0,0 +1,140 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// formats of EU VAT identification numbers (without the country prefix)
+var VATIDFormats = map[string]*regexp.Regexp{
+	"AT": regexp.MustCompile(`^U\d{8}$`),
+	"BE": regexp.MustCompile(`^[01]\d{9}$`),
+	"BG": regexp.MustCompile(`^\d{9,10}$`),
+	"CY": regexp.MustCompile(`^\d{8}[A-Z]$`),
+	"CZ": regexp.MustCompile(`^\d{8,10}$`),
+	"DE": regexp.MustCompile(`^\d{9}$`),
+	"DK": regexp.MustCompile(`^\d{8}$`),
+	"EE": regexp.MustCompile(`^\d{9}$`),
+	"EL": regexp.MustCompile(`^\d{9}$`),
+	"ES": regexp.MustCompile(`^[A-Z0-9]\d{7}[A-Z0-9]$`),
+	"FI": regexp.MustCompile(`^\d{8}$`),
+	"FR": regexp.MustCompile(`^[A-HJ-NP-Z0-9]{2}\d{9}$`),
+	"HR": regexp.MustCompile(`^\d{11}$`),
+	"HU": regexp.MustCompile(`^\d{8}$`),
+	"IE": regexp.MustCompile(`^(\d{7}[A-W][A-I]?|\d[A-Z+*]\d{5}[A-W])$`),
+	"IT": regexp.MustCompile(`^\d{11}$`),
+	"LT": regexp.MustCompile(`^(\d{9}|\d{12})$`),
+	"LU": regexp.MustCompile(`^\d{8}$`),
+	"LV": regexp.MustCompile(`^\d{11}$`),
+	"MT": regexp.MustCompile(`^\d{8}$`),
+	"NL": regexp.MustCompile(`^\d{9}B\d{2}$`),
+	"PL": regexp.MustCompile(`^\d{10}$`),
+	"PT": regexp.MustCompile(`^\d{9}$`),
+	"RO": regexp.MustCompile(`^\d{2,10}$`),
+	"SE": regexp.MustCompile(`^\d{10}01$`),
+	"SI": regexp.MustCompile(`^\d{8}$`),
+	"SK": regexp.MustCompile(`^\d{10}$`),
+	"XI": regexp.MustCompile(`^(\d{9}|\d{12}|GD\d{3}|HA\d{3})$`),
+}
+
+var IsVATIDForm = Form{
+	Fields: []Field{
+		{
+			Name: "countries",
+			Validators: []Validator{
+				IsOptional{},
+				IsStringList{},
+			},
+		},
+		{
+			Name: "mask",
+			Validators: []Validator{
+				IsOptional{Default: false},
+				IsBoolean{},
+			},
+		},
+	},
+}
+
+func MakeIsVATIDValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+	isVATID := &IsVATID{}
+	if params, err := IsVATIDForm.Validate(config); err != nil {
+		return nil, err
+	} else if err := IsVATIDForm.Coerce(isVATID, params); err != nil {
+		return nil, err
+	}
+	return isVATID, nil
+}
+
+// IsVATID checks the format of an EU VAT identification number including its
+// country prefix (e.g. "DE123456789"). Greek numbers use the "EL" prefix,
+// "GR" is accepted and converted. The number is returned without spaces,
+// dots and dashes and in upper case.
+type IsVATID struct {
+	Countries []string `json:"countries,omitempty"`
+	Mask      bool     `json:"mask"`
+}
+
+func (f IsVATID) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+	str, ok := input.(string)
+	if !ok {
+		return nil, fmt.Errorf("IsVATID: expected a string")
+	}
+
+	vatID := normalizeIdentifier(str, ".-")
+
+	if len(vatID) < 4 {
+		return nil, fmt.Errorf("not a valid VAT ID")
+	}
+
+	country, number := vatID[:2], vatID[2:]
+
+	if country == "GR" {
+		country = "EL"
+	}
+
+	format, ok := VATIDFormats[country]
+
+	if !ok {
+		return nil, fmt.Errorf("unknown VAT ID country code: %s", country)
+	}
+
+	if len(f.Countries) > 0 {
+		found := false
+		for _, allowedCountry := range f.Countries {
+			allowedCountry = strings.ToUpper(allowedCountry)
+			if allowedCountry == country || (allowedCountry == "GR" && country == "EL") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("VAT IDs from %s are not allowed", country)
+		}
+	}
+
+	if !format.MatchString(number) {
+		return nil, fmt.Errorf("not a valid VAT ID for country %s", country)
+	}
+
+	if f.Mask {
+		return country + maskString(number, 0, 3), nil
+	}
+
+	return country + number, nil
+}
@@ -62,8 +62,12 @@ func MakeMatchesRegexValidator(config map[string]interface{}, context *FormDescr
 }
 
 func (f MatchesRegex) Serialize() (map[string]interface{}, error) {
+	source := f.Source
+	if f.Regexp != nil {
+		source = f.Regexp.String()
+	}
 	return map[string]interface{}{
-		"regexp": f.Regexp.String(),
+		"regexp": source,
 	}, nil
 }
 
@@ -77,8 +81,15 @@ func (f MatchesRegex) Validate(input interface{}, values map[string]interface{})
 	if !ok {
 		return nil, fmt.Errorf("MatchesRegex: expected a string")
 	}
-	if matched := f.Regexp.Match([]byte(value)); !matched {
-		return nil, fmt.Errorf("regex '%s' did not match", f.Regexp.String())
+	re := f.Regexp
+	if re == nil {
+		var err error
+		if re, err = regexp.Compile(f.Source); err != nil {
+			return nil, fmt.Errorf("MatchesRegex: %v", err)
+		}
+	}
+	if matched := re.Match([]byte(value)); !matched {
+		return nil, fmt.Errorf("regex '%s' did not match", re.String())
 	}
 	return value, nil
 }
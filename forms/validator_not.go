@@ -0,0 +1,108 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"fmt"
+)
+
+var NotForm = Form{
+	Fields: []Field{
+		{
+			Name: "validators",
+			Validators: []Validator{
+				IsList{
+					Validators: []Validator{
+						IsStringMap{
+							Form: &ValidatorDescriptionForm,
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "message",
+			Validators: []Validator{
+				IsOptional{},
+				IsString{},
+			},
+		},
+	},
+}
+
+func (f Not) Serialize() (map[string]interface{}, error) {
+	return f.serialize(DefaultRegistry.serializer())
+}
+
+func (f Not) serialize(s *serializer) (map[string]interface{}, error) {
+	if validators, err := s.serializeValidators(f.Validators); err != nil {
+		return nil, err
+	} else {
+		config := map[string]interface{}{
+			"validators": validators,
+		}
+		if f.Message != "" {
+			config["message"] = f.Message
+		}
+		return config, nil
+	}
+}
+
+func MakeNotValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+	not := &Not{}
+	if params, err := NotForm.Validate(config); err != nil {
+		return nil, err
+	} else if err := NotForm.Coerce(not, params); err != nil {
+		return nil, err
+	} else {
+		validators := []Validator{}
+		for i, validatorDescription := range not.ValidatorDescriptions {
+			if validator, err := ValidatorFromDescription(validatorDescription, context); err != nil {
+				return nil, makeConfigError(fmt.Sprintf("validators[%d]", i), err)
+			} else {
+				validators = append(validators, validator)
+			}
+		}
+		not.Validators = validators
+	}
+	return not, nil
+}
+
+// Not succeeds if the given chain of validators fails, in which case the
+// input is returned unchanged. Message can be used to customize the error
+// that is returned otherwise.
+type Not struct {
+	ValidatorDescriptions []*ValidatorDescription `json:"validators"`
+	Validators            []Validator             `json:"-"`
+	Message               string                  `json:"message,omitempty"`
+}
+
+func (f Not) Validate(input interface{}, inputs map[string]interface{}) (interface{}, error) {
+	return f.validate(input, inputs, nil)
+}
+
+func (f Not) ValidateWithContext(input interface{}, inputs map[string]interface{}, context map[string]interface{}) (interface{}, error) {
+	return f.validate(input, inputs, context)
+}
+
+func (f Not) validate(input interface{}, inputs map[string]interface{}, context map[string]interface{}) (interface{}, error) {
+	if _, err := validateOption(f.Validators, input, inputs, context); err != nil {
+		return input, nil
+	}
+	if f.Message != "" {
+		return nil, fmt.Errorf("%s", f.Message)
+	}
+	return nil, fmt.Errorf("value must not match")
+}
@@ -0,0 +1,108 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"fmt"
+	"strings"
+)
+
+var OneOfForm = Form{
+	Fields: []Field{
+		{
+			Name: "options",
+			Validators: []Validator{
+				IsOptional{Default: []map[string]any{}},
+				IsList{
+					Validators: []Validator{
+						IsList{
+							Validators: []Validator{
+								IsStringMap{
+									Form: &ValidatorDescriptionForm,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+func (f OneOf) Serialize() (map[string]interface{}, error) {
+	return f.serialize(DefaultRegistry.serializer())
+}
+
+func (f OneOf) serialize(s *serializer) (map[string]interface{}, error) {
+	if optionDescriptions, err := serializeOptions(f.Options, s); err != nil {
+		return nil, err
+	} else {
+		return map[string]interface{}{
+			"options": optionDescriptions,
+		}, nil
+	}
+}
+
+func MakeOneOfValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+	oneOf := &OneOf{}
+	if params, err := OneOfForm.Validate(config); err != nil {
+		return nil, err
+	} else if err := OneOfForm.Coerce(oneOf, params); err != nil {
+		return nil, err
+	} else if options, err := makeOptions(oneOf.OptionsDescriptions, context); err != nil {
+		return nil, err
+	} else {
+		oneOf.Options = options
+	}
+	return oneOf, nil
+}
+
+// OneOf checks that exactly one option (a chain of validators) succeeds and
+// returns its value. If no option succeeds, the individual errors are
+// returned in a FormError, keyed by the index of the option.
+type OneOf struct {
+	OptionsDescriptions [][]*ValidatorDescription `json:"options"`
+	Options             [][]Validator             `json:"-"`
+}
+
+func (f OneOf) Validate(input interface{}, inputs map[string]interface{}) (interface{}, error) {
+	return f.validate(input, inputs, nil)
+}
+
+func (f OneOf) ValidateWithContext(input interface{}, inputs map[string]interface{}, context map[string]interface{}) (interface{}, error) {
+	return f.validate(input, inputs, context)
+}
+
+func (f OneOf) validate(input interface{}, inputs map[string]interface{}, context map[string]interface{}) (interface{}, error) {
+	errors := map[string]interface{}{}
+	matches := []string{}
+	var result interface{}
+	for i, option := range f.Options {
+		if value, err := validateOption(option, input, inputs, context); err == nil {
+			matches = append(matches, fmt.Sprintf("%d", i))
+			result = value
+		} else {
+			errors[fmt.Sprintf("%d", i)] = err
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, MakeFormError("no option matched", "FORM-ERROR", errors, nil)
+	case 1:
+		return result, nil
+	default:
+		return nil, fmt.Errorf("more than one option matched (options %s)", strings.Join(matches, ", "))
+	}
+}
@@ -14,10 +14,20 @@
 
 package forms
 
+import (
+	"fmt"
+)
+
+// OnlyIf returns the input if Function returns true and nil otherwise. As it
+// contains a function it cannot be serialized.
 type OnlyIf struct {
 	Function func(interface{}, map[string]interface{}) bool `json:"-"`
 }
 
+func (f OnlyIf) Serialize() (map[string]interface{}, error) {
+	return nil, fmt.Errorf("OnlyIf: validators with a function cannot be serialized")
+}
+
 func (f OnlyIf) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
 	if f.Function(input, values) == true {
 		return input, nil
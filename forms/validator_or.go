@@ -40,18 +40,64 @@ var OrForm = Form{
 	},
 }
 
-func (f Or) Serialize() (map[string]interface{}, error) {
-	optionDescriptions := make([][]*ValidatorDescription, len(f.Options))
-	for i, option := range f.Options {
-		if descriptions, err := SerializeValidators(option); err != nil {
+// serializes a list of validator chains
+func serializeOptions(options [][]Validator, s *serializer) ([][]*ValidatorDescription, error) {
+	optionDescriptions := make([][]*ValidatorDescription, len(options))
+	for i, option := range options {
+		if descriptions, err := s.serializeValidators(option); err != nil {
 			return nil, err
 		} else {
 			optionDescriptions[i] = descriptions
 		}
 	}
-	return map[string]interface{}{
-		"options": optionDescriptions,
-	}, nil
+	return optionDescriptions, nil
+}
+
+// creates validator chains from their descriptions
+func makeOptions(optionsDescriptions [][]*ValidatorDescription, context *FormDescriptionContext) ([][]Validator, error) {
+	options := [][]Validator{}
+	for i, optionDescription := range optionsDescriptions {
+		validators := []Validator{}
+		for j, validatorDescription := range optionDescription {
+			if validator, err := ValidatorFromDescription(validatorDescription, context); err != nil {
+				return nil, makeConfigError(fmt.Sprintf("options[%d][%d]", i, j), err)
+			} else {
+				validators = append(validators, validator)
+			}
+		}
+		options = append(options, validators)
+	}
+	return options, nil
+}
+
+// runs the input through a chain of validators
+func validateOption(option []Validator, input interface{}, inputs map[string]interface{}, context map[string]interface{}) (interface{}, error) {
+	value := input
+	var err error
+	for _, validator := range option {
+		if contextValidator, ok := validator.(ContextValidator); ok && context != nil {
+			if value, err = contextValidator.ValidateWithContext(value, inputs, context); err != nil {
+				return nil, err
+			}
+		} else if value, err = validator.Validate(value, inputs); err != nil {
+			return nil, err
+		}
+	}
+	return value, nil
+}
+
+func (f Or) Serialize() (map[string]interface{}, error) {
+	return f.serialize(DefaultRegistry.serializer())
+}
+
+func (f Or) serialize(s *serializer) (map[string]interface{}, error) {
+	if optionDescriptions, err := serializeOptions(f.Options, s); err != nil {
+		return nil, err
+	} else {
+		return map[string]interface{}{
+			"options": optionDescriptions,
+		}, nil
+	}
 }
 
 func MakeOrValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
@@ -60,24 +106,17 @@ func MakeOrValidator(config map[string]interface{}, context *FormDescriptionCont
 		return nil, err
 	} else if err := OrForm.Coerce(or, params); err != nil {
 		return nil, err
+	} else if options, err := makeOptions(or.OptionsDescriptions, context); err != nil {
+		return nil, err
 	} else {
-		options := [][]Validator{}
-		for _, optionDescription := range or.OptionsDescriptions {
-			validators := []Validator{}
-			for _, validatorDescription := range optionDescription {
-				if validator, err := ValidatorFromDescription(validatorDescription, context); err != nil {
-					return nil, err
-				} else {
-					validators = append(validators, validator)
-				}
-			}
-			options = append(options, validators)
-		}
 		or.Options = options
 	}
 	return or, nil
 }
 
+// Or returns the value of the first option (a chain of validators) that
+// succeeds. If no option succeeds, the individual errors are returned in a
+// FormError, keyed by the index of the option.
 type Or struct {
 	OptionsDescriptions [][]*ValidatorDescription `json:"options"`
 	Options             [][]Validator             `json:"-"`
@@ -92,21 +131,13 @@ func (f Or) ValidateWithContext(input interface{}, inputs map[string]interface{}
 }
 
 func (f Or) validate(input interface{}, inputs map[string]interface{}, context map[string]interface{}) (interface{}, error) {
-	for _, option := range f.Options {
-		value := input
-		var err error
-		for _, validator := range option {
-			if contextValidator, ok := validator.(ContextValidator); ok && context != nil {
-				if value, err = contextValidator.ValidateWithContext(value, inputs, context); err != nil {
-					break
-				}
-			} else if value, err = validator.Validate(value, inputs); err != nil {
-				break
-			}
-		}
-		if err == nil {
+	errors := map[string]interface{}{}
+	for i, option := range f.Options {
+		if value, err := validateOption(option, input, inputs, context); err == nil {
 			return value, nil
+		} else {
+			errors[fmt.Sprintf("%d", i)] = err
 		}
 	}
-	return nil, fmt.Errorf("no possible option worked out")
+	return nil, MakeFormError("no possible option worked out", "FORM-ERROR", errors, nil)
 }
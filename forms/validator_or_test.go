@@ -0,0 +1,141 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCombinatorsFromConfig(t *testing.T) {
+
+	option := func(validators ...map[string]interface{}) []map[string]interface{} {
+		return validators
+	}
+
+	isString := map[string]interface{}{"type": "IsString"}
+	isInteger := map[string]interface{}{"type": "IsInteger"}
+	isNumeric := map[string]interface{}{"type": "IsInteger", "config": map[string]interface{}{"convert": true}}
+	shortString := map[string]interface{}{"type": "IsString", "config": map[string]interface{}{"maxLength": 3}}
+
+	validator := func(validatorType string, config map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"type": validatorType, "config": config}
+	}
+
+	config := map[string]interface{}{
+		"fields": []map[string]interface{}{
+			{
+				"name": "or",
+				"validators": []map[string]interface{}{
+					{"type": "IsOptional"},
+					validator("Or", map[string]interface{}{"options": [][]map[string]interface{}{option(isString), option(isInteger)}}),
+				},
+			},
+			{
+				"name": "oneOf",
+				"validators": []map[string]interface{}{
+					{"type": "IsOptional"},
+					validator("OneOf", map[string]interface{}{"options": [][]map[string]interface{}{option(isNumeric), option(shortString)}}),
+				},
+			},
+			{
+				"name": "allOf",
+				"validators": []map[string]interface{}{
+					{"type": "IsOptional"},
+					validator("AllOf", map[string]interface{}{"options": [][]map[string]interface{}{option(shortString), option(isNumeric)}}),
+				},
+			},
+			{
+				"name": "not",
+				"validators": []map[string]interface{}{
+					{"type": "IsOptional"},
+					validator("Not", map[string]interface{}{"validators": option(isInteger), "message": "no integers please"}),
+				},
+			},
+		},
+	}
+
+	form, err := FromConfig(config, &FormDescriptionContext{Validators: Validators})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, testCase := range []struct {
+		Input  map[string]interface{}
+		Output map[string]interface{}
+	}{
+		{map[string]interface{}{"or": "foo"}, map[string]interface{}{"or": "foo"}},
+		{map[string]interface{}{"or": 4}, map[string]interface{}{"or": int64(4)}},
+		// "1234" is numeric but too long
+		{map[string]interface{}{"oneOf": "1234"}, map[string]interface{}{"oneOf": int64(1234)}},
+		{map[string]interface{}{"oneOf": "abc"}, map[string]interface{}{"oneOf": "abc"}},
+		// the value of the last option is returned
+		{map[string]interface{}{"allOf": "123"}, map[string]interface{}{"allOf": int64(123)}},
+		{map[string]interface{}{"not": "foo"}, map[string]interface{}{"not": "foo"}},
+	} {
+		output, err := form.Validate(testCase.Input)
+		if err != nil {
+			t.Errorf("case %d: %v", i, err)
+			continue
+		}
+		for key, value := range testCase.Output {
+			if output[key] != value {
+				t.Errorf("case %d: expected %v for '%s', got %v", i, value, key, output[key])
+			}
+		}
+	}
+
+	for i, input := range []map[string]interface{}{
+		{"or": 4.5},
+		// matches both options
+		{"oneOf": "123"},
+		{"oneOf": 4.5},
+		{"allOf": "1234"},
+		{"allOf": "abc"},
+		{"not": 4},
+	} {
+		if _, err := form.Validate(input); err == nil {
+			t.Errorf("case %d: expected an error", i)
+		}
+	}
+
+	or := form.Fields[0].Validators[1].(*Or)
+
+	if _, err := or.Validate(4.5, nil); err == nil {
+		t.Fatalf("expected an error")
+	} else if formError, ok := err.(*FormError); !ok {
+		t.Fatalf("expected a form error")
+	} else if errors := formError.Errors(); len(errors) != 2 || errors["0"] == nil || errors["1"] == nil {
+		t.Fatalf("expected an error for each option, got %v", errors)
+	}
+
+	for _, field := range form.Fields {
+		description, err := SerializeValidator(field.Validators[1])
+		if err != nil {
+			t.Fatal(err)
+		}
+		// we restore the validator from its JSON representation
+		restoredDescription := &ValidatorDescription{}
+		if data, err := json.Marshal(description); err != nil {
+			t.Fatal(err)
+		} else if err := json.Unmarshal(data, restoredDescription); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := ValidatorFromDescription(restoredDescription, &FormDescriptionContext{Validators: Validators}); err != nil {
+			t.Errorf("%s: cannot restore serialized validator: %v", field.Name, err)
+		}
+	}
+}
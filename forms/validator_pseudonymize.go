@@ -0,0 +1,134 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+const DefaultPseudonymizationKey = "pseudonymizationKey"
+
+var PseudonymizeForm = Form{
+	Fields: []Field{
+		{
+			Name: "key",
+			Validators: []Validator{
+				IsOptional{Default: DefaultPseudonymizationKey},
+				IsString{MinLength: 1},
+			},
+		},
+		{
+			Name: "encoding",
+			Validators: []Validator{
+				IsOptional{Default: "hex"},
+				IsIn{Choices: []interface{}{"hex", "base64", "base64-url", "binary"}},
+			},
+		},
+		{
+			Name: "length",
+			Validators: []Validator{
+				IsOptional{},
+				IsInteger{HasMin: true, Min: 0, HasMax: true, Max: sha256.Size},
+			},
+		},
+	},
+}
+
+func MakePseudonymizeValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+	pseudonymize := &Pseudonymize{}
+	if params, err := PseudonymizeForm.Validate(config); err != nil {
+		return nil, err
+	} else if err := PseudonymizeForm.Coerce(pseudonymize, params); err != nil {
+		return nil, err
+	}
+	return pseudonymize, nil
+}
+
+// Pseudonymize replaces an identifier with a keyed HMAC-SHA256 of it. The
+// key is looked up in the validation context under the name given by Key,
+// so it never becomes part of the (serialized) form itself.
+type Pseudonymize struct {
+	Key      string `json:"key"`
+	Encoding string `json:"encoding"`
+	Length   int    `json:"length,omitempty" coerce:"convert"`
+}
+
+func (f Pseudonymize) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+	return f.validate(input, values, nil)
+}
+
+func (f Pseudonymize) ValidateWithContext(input interface{}, values map[string]interface{}, context map[string]interface{}) (interface{}, error) {
+	return f.validate(input, values, context)
+}
+
+func (f Pseudonymize) validate(input interface{}, values map[string]interface{}, context map[string]interface{}) (interface{}, error) {
+
+	keyName := f.Key
+
+	if keyName == "" {
+		keyName = DefaultPseudonymizationKey
+	}
+
+	var key []byte
+
+	switch v := context[keyName].(type) {
+	case []byte:
+		key = v
+	case string:
+		key = []byte(v)
+	}
+
+	if len(key) == 0 {
+		return nil, fmt.Errorf("Pseudonymize: no key '%s' found in context", keyName)
+	}
+
+	var data []byte
+
+	switch v := input.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	case int, int64, float64:
+		data = []byte(fmt.Sprint(v))
+	default:
+		return nil, fmt.Errorf("Pseudonymize: expected a string")
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	digest := mac.Sum(nil)
+
+	if f.Length > 0 && f.Length < len(digest) {
+		digest = digest[:f.Length]
+	}
+
+	switch f.Encoding {
+	case "", "hex":
+		return hex.EncodeToString(digest), nil
+	case "base64":
+		return base64.StdEncoding.EncodeToString(digest), nil
+	case "base64-url":
+		return base64.URLEncoding.EncodeToString(digest), nil
+	case "binary":
+		return digest, nil
+	default:
+		return nil, fmt.Errorf("invalid encoding: %s", f.Encoding)
+	}
+}
@@ -0,0 +1,111 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPseudonymize(t *testing.T) {
+	form := Form{
+		Fields: []Field{
+			{
+				Name: "id",
+				Validators: []Validator{
+					IsString{},
+					Pseudonymize{Length: 8},
+				},
+			},
+		},
+	}
+
+	if _, err := form.Validate(map[string]interface{}{"id": "foo"}); err == nil {
+		t.Fatalf("expected an error without a key")
+	}
+
+	context := map[string]interface{}{DefaultPseudonymizationKey: []byte("secret")}
+
+	a, err := form.ValidateWithContext(map[string]interface{}{"id": "foo"}, context)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := form.ValidateWithContext(map[string]interface{}{"id": "foo"}, context)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := form.ValidateWithContext(map[string]interface{}{"id": "foo"}, map[string]interface{}{DefaultPseudonymizationKey: "other"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if a["id"] != b["id"] {
+		t.Fatalf("expected stable pseudonyms")
+	}
+
+	if a["id"] == c["id"] {
+		t.Fatalf("expected different pseudonyms for different keys")
+	}
+
+	if len(a["id"].(string)) != 16 {
+		t.Fatalf("expected a truncated pseudonym, got '%v'", a["id"])
+	}
+}
+
+func TestAnonymizationValidators(t *testing.T) {
+	date := time.Date(2023, 5, 17, 13, 45, 0, 0, time.UTC)
+
+	for i, testCase := range []struct {
+		Validator Validator
+		Input     interface{}
+		Output    interface{}
+	}{
+		{TruncateIP{}, "192.168.17.42", "192.168.17.0"},
+		{TruncateIP{IPv4Bits: 16}, "192.168.17.42", "192.168.0.0"},
+		{TruncateIP{}, "2001:db8:85a3:8d3:1319:8a2e:370:7348", "2001:db8:85a3::"},
+		{GeneralizeDate{}, "2023-05-17", "2023-05"},
+		{GeneralizeDate{Granularity: "year"}, "2023-05-17T13:45:00Z", "2023"},
+		{GeneralizeDate{Granularity: "month"}, date, time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)},
+		{GeneralizeNumber{BucketSize: 10}, 37, int64(30)},
+		{GeneralizeNumber{BucketSize: 10}, -3, int64(-10)},
+		{GeneralizeNumber{BucketSize: 0.5}, 1.7, 1.5},
+		{RetainEmailDomain{}, "Max.Mustermann@Example.COM", "example.com"},
+		{RetainEmailDomain{Mask: "***"}, "max@example.com", "***@example.com"},
+	} {
+		output, err := testCase.Validator.Validate(testCase.Input, nil)
+		if err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		if output != testCase.Output {
+			t.Fatalf("case %d: expected '%v', got '%v'", i, testCase.Output, output)
+		}
+	}
+
+	for i, testCase := range []struct {
+		Validator Validator
+		Input     interface{}
+	}{
+		{TruncateIP{}, "not an ip"},
+		{GeneralizeDate{}, "2023-13-01"},
+		{GeneralizeNumber{BucketSize: 10}, "10"},
+		{RetainEmailDomain{}, "example.com"},
+	} {
+		if _, err := testCase.Validator.Validate(testCase.Input, nil); err == nil {
+			t.Fatalf("case %d: expected an error", i)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"fmt"
+	"strings"
+)
+
+var RetainEmailDomainForm = Form{
+	Fields: []Field{
+		{
+			Name: "mask",
+			Validators: []Validator{
+				IsOptional{},
+				IsString{},
+			},
+		},
+	},
+}
+
+func MakeRetainEmailDomainValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+	retainEmailDomain := &RetainEmailDomain{}
+	if params, err := RetainEmailDomainForm.Validate(config); err != nil {
+		return nil, err
+	} else if err := RetainEmailDomainForm.Coerce(retainEmailDomain, params); err != nil {
+		return nil, err
+	}
+	return retainEmailDomain, nil
+}
+
+// RetainEmailDomain drops the local part of an e-mail address and returns
+// only its (lowercased) domain. If Mask is given, the local part is replaced
+// by it instead (e.g. "***@example.com").
+type RetainEmailDomain struct {
+	Mask string `json:"mask,omitempty"`
+}
+
+func (f RetainEmailDomain) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+	str, ok := input.(string)
+	if !ok {
+		return nil, fmt.Errorf("RetainEmailDomain: expected a string")
+	}
+	i := strings.LastIndex(str, "@")
+	if i <= 0 || i == len(str)-1 {
+		return nil, fmt.Errorf("not a valid e-mail address")
+	}
+	domain := strings.ToLower(strings.TrimSpace(str[i+1:]))
+	if domain == "" || strings.ContainsAny(domain, " @") {
+		return nil, fmt.Errorf("not a valid e-mail address")
+	}
+	if f.Mask != "" {
+		return f.Mask + "@" + domain, nil
+	}
+	return domain, nil
+}
@@ -16,7 +16,10 @@ package forms
 
 import (
 	"fmt"
+	"regexp"
 	"sort"
+	"strconv"
+	"strings"
 )
 
 var CasesForm = Form{
@@ -47,6 +50,33 @@ var SwitchForm = Form{
 				IsString{},
 			},
 		},
+		{
+			Name: "match",
+			Validators: []Validator{
+				IsOptional{Default: "exact"},
+				IsIn{Choices: []interface{}{"exact", "prefix", "regex"}},
+			},
+		},
+		{
+			Name: "exhaustive",
+			Validators: []Validator{
+				IsOptional{Default: false},
+				IsBoolean{},
+			},
+		},
+		{
+			Name: "default",
+			Validators: []Validator{
+				IsOptional{},
+				IsList{
+					Validators: []Validator{
+						IsStringMap{
+							Form: &ValidatorDescriptionForm,
+						},
+					},
+				},
+			},
+		},
 		{
 			Name: "cases",
 			Validators: []Validator{
@@ -60,27 +90,36 @@ var SwitchForm = Form{
 }
 
 func (f Switch) Serialize() (map[string]interface{}, error) {
+	return f.serialize(DefaultRegistry.serializer())
+}
+
+func (f Switch) serialize(s *serializer) (map[string]interface{}, error) {
 	casesDescriptions := make(map[string][]*ValidatorDescription)
 	for key, validators := range f.Cases {
-		if descriptions, err := SerializeValidators(validators); err != nil {
+		if descriptions, err := s.serializeValidators(validators); err != nil {
 			return nil, err
 		} else {
 			casesDescriptions[key] = descriptions
 		}
 	}
 
-	defaultDescriptions, err := SerializeValidators(f.Default)
-
-	if err != nil {
-		return nil, err
-	}
-
-	return map[string]interface{}{
+	config := map[string]interface{}{
 		"key":        f.Key,
+		"match":      f.Match,
 		"exhaustive": f.Exhaustive,
-		"default":    defaultDescriptions,
 		"cases":      casesDescriptions,
-	}, nil
+	}
+
+	// an empty default case is different from no default case
+	if f.Default != nil {
+		if defaultDescriptions, err := s.serializeValidators(f.Default); err != nil {
+			return nil, err
+		} else {
+			config["default"] = defaultDescriptions
+		}
+	}
+
+	return config, nil
 }
 
 func MakeSwitchValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
@@ -105,9 +144,9 @@ func MakeSwitchValidator(config map[string]interface{}, context *FormDescription
 		for _, key := range values {
 			caseDescription := switchValidator.CasesDescriptions[key]
 			validators := []Validator{}
-			for _, validatorDescription := range caseDescription {
+			for i, validatorDescription := range caseDescription {
 				if validator, err := ValidatorFromDescription(validatorDescription, context); err != nil {
-					return nil, err
+					return nil, makeConfigError(fmt.Sprintf("cases.%s[%d]", key, i), err)
 				} else {
 					validators = append(validators, validator)
 				}
@@ -115,27 +154,186 @@ func MakeSwitchValidator(config map[string]interface{}, context *FormDescription
 			cases[key] = validators
 		}
 		switchValidator.Cases = cases
+
+		if switchValidator.DefaultDescriptions != nil {
+			validators := []Validator{}
+			for i, validatorDescription := range switchValidator.DefaultDescriptions {
+				if validator, err := ValidatorFromDescription(validatorDescription, context); err != nil {
+					return nil, makeConfigError(fmt.Sprintf("default[%d]", i), err)
+				} else {
+					validators = append(validators, validator)
+				}
+			}
+			switchValidator.Default = validators
+		}
+
+		if switchValidator.Match == "regex" {
+			// we compile the patterns only once (in the order of evaluation)
+			if switchValidator.patterns, err = compileSwitchPatterns(values); err != nil {
+				return nil, err
+			}
+		}
 	}
 	return switchValidator, nil
 }
 
+// Switch applies the validators of the case that matches the value of the
+// field given by Key. Key can be a dotted path into nested maps (e.g.
+// "meta.type"), paths starting with "_parent" are looked up in the values of
+// the parent form. The value can be a string, an integer or a boolean and is
+// compared to the case names according to Match: "exact" (the default)
+// requires an exact match, "prefix" selects the case with the longest
+// matching prefix and "regex" treats case names as regular expressions and
+// selects the first matching case in alphabetical order. If no case matches,
+// the Default validators are applied if defined.
 type Switch struct {
 	Key                 string                             `json:"key"`
+	Match               string                             `json:"match"`
 	Exhaustive          bool                               `json:"exhaustive"`
 	Default             []Validator                        `json:"-"`
 	Cases               map[string][]Validator             `json:"-"`
 	CasesDescriptions   map[string][]*ValidatorDescription `json:"cases"`
 	DefaultDescriptions []*ValidatorDescription            `json:"default"`
+	// the compiled case patterns for regex matching, in alphabetical order
+	patterns []switchPattern
+}
+
+type switchPattern struct {
+	key    string
+	regexp *regexp.Regexp
+}
+
+// compiles the given case patterns, which must be sorted alphabetically
+func compileSwitchPatterns(keys []string) ([]switchPattern, error) {
+	patterns := make([]switchPattern, 0, len(keys))
+	for _, key := range keys {
+		re, err := regexp.Compile(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid case pattern '%s': %v", key, err)
+		}
+		patterns = append(patterns, switchPattern{key: key, regexp: re})
+	}
+	return patterns, nil
+}
+
+// looks up the switch key in the values or, for "_parent" paths, in the
+// values of the parent form
+func (f Switch) lookup(values map[string]interface{}, context map[string]interface{}) (interface{}, bool) {
+
+	// keys that contain a dot can still refer to a top-level field
+	if value, ok := values[f.Key]; ok {
+		return value, true
+	}
+
+	path := strings.Split(f.Key, ".")
+	current := interface{}(values)
+
+	if path[0] == "_parent" {
+		if context == nil {
+			return nil, false
+		}
+		current, path = context["_parent"], path[1:]
+	}
+
+	for _, component := range path {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[component]; !ok {
+			return nil, false
+		}
+	}
+
+	return current, true
+}
+
+// converts a switch value to the string used for matching cases
+func switchValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case int32:
+		return strconv.FormatInt(int64(v), 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case float64:
+		// numbers decoded from JSON are floats
+		if v == float64(int64(v)) {
+			return strconv.FormatInt(int64(v), 10), nil
+		}
+	}
+	return "", fmt.Errorf("switch key must be a string, an integer or a boolean")
+}
+
+// returns the validators of the case that matches the given value
+func (f Switch) match(value string) ([]Validator, bool, error) {
+	switch f.Match {
+	case "prefix":
+		var validators []Validator
+		found := false
+		longest := -1
+		for key, caseValidators := range f.Cases {
+			if strings.HasPrefix(value, key) && len(key) > longest {
+				validators, found, longest = caseValidators, true, len(key)
+			}
+		}
+		return validators, found, nil
+	case "regex":
+		patterns := f.patterns
+		if patterns == nil {
+			// the validator was not created by MakeSwitchValidator
+			keys := make([]string, 0, len(f.Cases))
+			for key := range f.Cases {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			var err error
+			if patterns, err = compileSwitchPatterns(keys); err != nil {
+				return nil, false, err
+			}
+		}
+		for _, pattern := range patterns {
+			if pattern.regexp.MatchString(value) {
+				return f.Cases[pattern.key], true, nil
+			}
+		}
+		return nil, false, nil
+	default:
+		validators, ok := f.Cases[value]
+		return validators, ok, nil
+	}
+}
+
+func (f Switch) ValidateWithContext(input interface{}, values map[string]interface{}, context map[string]interface{}) (interface{}, error) {
+	return f.validate(input, values, context)
 }
 
 func (f Switch) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
-	strValue, ok := values[f.Key].(string)
+	return f.validate(input, values, nil)
+}
 
-	if !ok {
-		return nil, fmt.Errorf("switch key is not a string")
+func (f Switch) validate(input interface{}, values map[string]interface{}, context map[string]interface{}) (interface{}, error) {
+
+	rawValue, _ := f.lookup(values, context)
+
+	strValue, err := switchValue(rawValue)
+
+	if err != nil {
+		return nil, err
 	}
 
-	caseValue, ok := f.Cases[strValue]
+	caseValue, ok, err := f.match(strValue)
+
+	if err != nil {
+		return nil, err
+	}
 
 	if !ok {
 
@@ -157,10 +355,12 @@ func (f Switch) Validate(input interface{}, values map[string]interface{}) (inte
 
 	}
 
-	var err error
-
 	for _, validator := range caseValue {
-		input, err = validator.Validate(input, values)
+		if contextValidator, ok := validator.(ContextValidator); ok && context != nil {
+			input, err = contextValidator.ValidateWithContext(input, values, context)
+		} else {
+			input, err = validator.Validate(input, values)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -71,3 +71,132 @@ func TestSwitchFromConfig(t *testing.T) {
 		t.Fatalf("expected an error")
 	}
 }
+
+func TestSwitchKeyPathsAndMatching(t *testing.T) {
+
+	isString := []Validator{IsString{}}
+	isInteger := []Validator{IsInteger{}}
+
+	for i, testCase := range []struct {
+		Switch Switch
+		Values map[string]interface{}
+		Input  interface{}
+		Valid  bool
+	}{
+		{Switch{Key: "meta.type", Cases: map[string][]Validator{"string": isString}}, map[string]interface{}{"meta": map[string]interface{}{"type": "string"}}, "foo", true},
+		{Switch{Key: "meta.type", Cases: map[string][]Validator{"string": isString}}, map[string]interface{}{"meta": map[string]interface{}{"type": "string"}}, 4, false},
+		{Switch{Key: "version", Cases: map[string][]Validator{"2": isInteger}}, map[string]interface{}{"version": 2}, 4, true},
+		{Switch{Key: "version", Cases: map[string][]Validator{"2": isInteger}}, map[string]interface{}{"version": 2.0}, 4, true},
+		{Switch{Key: "enabled", Cases: map[string][]Validator{"true": isString}}, map[string]interface{}{"enabled": true}, "foo", true},
+		{Switch{Key: "enabled", Cases: map[string][]Validator{"true": isString}}, map[string]interface{}{"enabled": 1.5}, "foo", false},
+		{Switch{Key: "type", Match: "prefix", Cases: map[string][]Validator{"int": isString, "integer": isInteger}}, map[string]interface{}{"type": "integer64"}, 4, true},
+		{Switch{Key: "type", Match: "prefix", Cases: map[string][]Validator{"int": isString, "integer": isInteger}}, map[string]interface{}{"type": "int32"}, "foo", true},
+		{Switch{Key: "type", Match: "regex", Cases: map[string][]Validator{"^str(ing)?$": isString}}, map[string]interface{}{"type": "str"}, "foo", true},
+		{Switch{Key: "type", Match: "regex", Cases: map[string][]Validator{"^str(ing)?$": isString}}, map[string]interface{}{"type": "strings"}, "foo", false},
+		{Switch{Key: "type", Default: isInteger, Cases: map[string][]Validator{"string": isString}}, map[string]interface{}{"type": "other"}, 4, true},
+	} {
+		_, err := testCase.Switch.Validate(testCase.Input, testCase.Values)
+		if testCase.Valid && err != nil {
+			t.Errorf("case %d: %v", i, err)
+		} else if !testCase.Valid && err == nil {
+			t.Errorf("case %d: expected an error", i)
+		}
+	}
+
+	// the switch key can refer to the parent form
+	form := Form{
+		Fields: []Field{
+			{Name: "type", Validators: []Validator{IsString{}}},
+			{
+				Name: "data",
+				Validators: []Validator{
+					IsStringMap{
+						Form: &Form{
+							Fields: []Field{
+								{
+									Name: "value",
+									Validators: []Validator{
+										Switch{Key: "_parent.type", Exhaustive: true, Cases: map[string][]Validator{"integer": isInteger, "string": isString}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if _, err := form.Validate(map[string]interface{}{"type": "integer", "data": map[string]interface{}{"value": 4}}); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := form.Validate(map[string]interface{}{"type": "integer", "data": map[string]interface{}{"value": "foo"}}); err == nil {
+		t.Errorf("expected an error")
+	}
+}
+
+func TestSwitchSerialization(t *testing.T) {
+	context := &FormDescriptionContext{
+		Validators: Validators,
+	}
+
+	validator, err := MakeSwitchValidator(map[string]interface{}{
+		"key":        "type",
+		"match":      "prefix",
+		"exhaustive": true,
+		"default":    []interface{}{map[string]interface{}{"type": "IsInteger"}},
+		"cases": map[string]interface{}{
+			"str": []interface{}{map[string]interface{}{"type": "IsString"}},
+		},
+	}, context)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := validator.(*Switch).Serialize()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if config["match"] != "prefix" || config["exhaustive"] != true || len(config["default"].([]*ValidatorDescription)) != 1 {
+		t.Fatalf("unexpected config: %v", config)
+	}
+
+	// switches without a default case should not get one when serialized
+	if config, err := (Switch{Key: "type"}).Serialize(); err != nil {
+		t.Fatal(err)
+	} else if _, ok := config["default"]; ok {
+		t.Fatalf("expected no default case")
+	}
+
+	if _, err := MakeSwitchValidator(map[string]interface{}{"match": "regex", "cases": map[string]interface{}{"(": []interface{}{}}}, context); err == nil {
+		t.Fatalf("expected an error for an invalid pattern")
+	}
+
+	// regex patterns are compiled when the validator is created
+	regexValidator, err := MakeSwitchValidator(map[string]interface{}{
+		"key":   "type",
+		"match": "regex",
+		"cases": map[string]interface{}{
+			"^int":        []interface{}{map[string]interface{}{"type": "IsInteger"}},
+			"^str(ing)?$": []interface{}{map[string]interface{}{"type": "IsString"}},
+		},
+	}, context)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if patterns := regexValidator.(*Switch).patterns; len(patterns) != 2 || patterns[0].key != "^int" {
+		t.Fatalf("expected compiled patterns in alphabetical order but got %v", patterns)
+	}
+
+	if _, err := regexValidator.Validate("foo", map[string]interface{}{"type": "string"}); err != nil {
+		t.Fatal(err)
+	} else if _, err := regexValidator.Validate("foo", map[string]interface{}{"type": "integer"}); err == nil {
+		t.Fatalf("expected an error for the integer case")
+	}
+}
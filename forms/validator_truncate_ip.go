@@ -0,0 +1,80 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"fmt"
+	"net"
+)
+
+var TruncateIPForm = Form{
+	Fields: []Field{
+		{
+			Name: "ipv4Bits",
+			Validators: []Validator{
+				IsOptional{Default: int64(24)},
+				IsInteger{HasMin: true, Min: 1, HasMax: true, Max: 32},
+			},
+		},
+		{
+			Name: "ipv6Bits",
+			Validators: []Validator{
+				IsOptional{Default: int64(48)},
+				IsInteger{HasMin: true, Min: 1, HasMax: true, Max: 128},
+			},
+		},
+	},
+}
+
+func MakeTruncateIPValidator(config map[string]interface{}, context *FormDescriptionContext) (Validator, error) {
+	truncateIP := &TruncateIP{}
+	if params, err := TruncateIPForm.Validate(config); err != nil {
+		return nil, err
+	} else if err := TruncateIPForm.Coerce(truncateIP, params); err != nil {
+		return nil, err
+	}
+	return truncateIP, nil
+}
+
+// TruncateIP keeps only the network prefix of an IP address, i.e. the first
+// IPv4Bits (IPv6Bits) bits, and sets all remaining bits to zero. If no prefix
+// lengths are given we keep 24 bits for IPv4 and 48 bits for IPv6 addresses.
+type TruncateIP struct {
+	IPv4Bits int `json:"ipv4Bits" coerce:"convert"`
+	IPv6Bits int `json:"ipv6Bits" coerce:"convert"`
+}
+
+func (f TruncateIP) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+	str, ok := input.(string)
+	if !ok {
+		return nil, fmt.Errorf("TruncateIP: expected a string")
+	}
+	ip := net.ParseIP(str)
+	if ip == nil {
+		return nil, fmt.Errorf("not a valid IP address")
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		bits := f.IPv4Bits
+		if bits == 0 {
+			bits = 24
+		}
+		return ip4.Mask(net.CIDRMask(bits, 32)).String(), nil
+	}
+	bits := f.IPv6Bits
+	if bits == 0 {
+		bits = 48
+	}
+	return ip.Mask(net.CIDRMask(bits, 128)).String(), nil
+}
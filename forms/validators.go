@@ -19,25 +19,54 @@ type ValidatorDefinition struct {
 	Form  Form
 }
 
+// Validators contains the built-in validators and is used to populate
+// DefaultRegistry when the package is initialized.
+//
+// Deprecated: changes to this map are not reflected in DefaultRegistry, use
+// DefaultRegistry (or a custom Registry) instead.
 var Validators = map[string]ValidatorDefinition{
-	"IsNil":         ValidatorDefinition{MakeIsNilValidator, IsNilForm},
-	"IsString":      ValidatorDefinition{MakeIsStringValidator, IsStringForm},
-	"IsStringList":  ValidatorDefinition{MakeIsStringListValidator, IsStringListForm},
-	"CanBeAnything": ValidatorDefinition{MakeCanBeAnythingValidator, CanBeAnythingForm},
-	"IsBytes":       ValidatorDefinition{MakeIsBytesValidator, IsBytesForm},
-	"IsBoolean":     ValidatorDefinition{MakeIsBooleanValidator, IsBooleanForm},
-	"IsFloat":       ValidatorDefinition{MakeIsFloatValidator, IsFloatForm},
-	"IsHex":         ValidatorDefinition{MakeIsHexValidator, IsHexForm},
-	"IsIn":          ValidatorDefinition{MakeIsInValidator, IsInForm},
-	"IsInteger":     ValidatorDefinition{MakeIsIntegerValidator, IsIntegerForm},
-	"IsList":        ValidatorDefinition{MakeIsListValidator, IsListForm},
-	"IsNotIn":       ValidatorDefinition{MakeIsNotInValidator, IsNotInForm},
-	"IsOptional":    ValidatorDefinition{MakeIsOptionalValidator, IsOptionalForm},
-	"IsRequired":    ValidatorDefinition{MakeIsRequiredValidator, IsRequiredForm},
-	"IsStringMap":   ValidatorDefinition{MakeIsStringMapValidator, IsStringMapForm},
-	"IsTime":        ValidatorDefinition{MakeIsTimeValidator, IsTimeForm},
-	"IsUUID":        ValidatorDefinition{MakeIsUUIDValidator, IsUUIDForm},
-	"MatchesRegex":  ValidatorDefinition{MakeMatchesRegexValidator, MatchesRegexForm},
-	"Or":            ValidatorDefinition{MakeOrValidator, OrForm},
-	"Switch":        ValidatorDefinition{MakeSwitchValidator, SwitchForm},
+	"IsNil":              ValidatorDefinition{MakeIsNilValidator, IsNilForm},
+	"IsPhoneNumber":      ValidatorDefinition{MakeIsPhoneNumberValidator, IsPhoneNumberForm},
+	"IsSemver":           ValidatorDefinition{MakeIsSemverValidator, IsSemverForm},
+	"IsSemverConstraint": ValidatorDefinition{MakeIsSemverConstraintValidator, IsSemverConstraintForm},
+	"IsString":           ValidatorDefinition{MakeIsStringValidator, IsStringForm},
+	"IsStringList":       ValidatorDefinition{MakeIsStringListValidator, IsStringListForm},
+	"AllOf":              ValidatorDefinition{MakeAllOfValidator, AllOfForm},
+	"CanBeAnything":      ValidatorDefinition{MakeCanBeAnythingValidator, CanBeAnythingForm},
+	"IsBytes":            ValidatorDefinition{MakeIsBytesValidator, IsBytesForm},
+	"IsCronExpression":   ValidatorDefinition{MakeIsCronExpressionValidator, IsCronExpressionForm},
+	"IsCurrencyCode":     ValidatorDefinition{MakeIsCurrencyCodeValidator, IsCurrencyCodeForm},
+	"IsEncodedDocument":  ValidatorDefinition{MakeIsEncodedDocumentValidator, IsEncodedDocumentForm},
+	"IsBoolean":          ValidatorDefinition{MakeIsBooleanValidator, IsBooleanForm},
+	"IsBIC":              ValidatorDefinition{MakeIsBICValidator, IsBICForm},
+	"IsCoordinate":       ValidatorDefinition{MakeIsCoordinateValidator, IsCoordinateForm},
+	"IsCountryCode":      ValidatorDefinition{MakeIsCountryCodeValidator, IsCountryCodeForm},
+	"IsCreditCard":       ValidatorDefinition{MakeIsCreditCardValidator, IsCreditCardForm},
+	"IsFloat":            ValidatorDefinition{MakeIsFloatValidator, IsFloatForm},
+	"IsGeoJSON":          ValidatorDefinition{MakeIsGeoJSONValidator, IsGeoJSONForm},
+	"IsHex":              ValidatorDefinition{MakeIsHexValidator, IsHexForm},
+	"IsIBAN":             ValidatorDefinition{MakeIsIBANValidator, IsIBANForm},
+	"IsIn":               ValidatorDefinition{MakeIsInValidator, IsInForm},
+	"IsInteger":          ValidatorDefinition{MakeIsIntegerValidator, IsIntegerForm},
+	"IsLanguageCode":     ValidatorDefinition{MakeIsLanguageCodeValidator, IsLanguageCodeForm},
+	"IsLanguageTag":      ValidatorDefinition{MakeIsLanguageTagValidator, IsLanguageTagForm},
+	"IsList":             ValidatorDefinition{MakeIsListValidator, IsListForm},
+	"IsNotIn":            ValidatorDefinition{MakeIsNotInValidator, IsNotInForm},
+	"IsOptional":         ValidatorDefinition{MakeIsOptionalValidator, IsOptionalForm},
+	"IsRequired":         ValidatorDefinition{MakeIsRequiredValidator, IsRequiredForm},
+	"IsStringMap":        ValidatorDefinition{MakeIsStringMapValidator, IsStringMapForm},
+	"IsTime":             ValidatorDefinition{MakeIsTimeValidator, IsTimeForm},
+	"IsUUID":             ValidatorDefinition{MakeIsUUIDValidator, IsUUIDForm},
+	"IsVATID":            ValidatorDefinition{MakeIsVATIDValidator, IsVATIDForm},
+	"MatchesRegex":       ValidatorDefinition{MakeMatchesRegexValidator, MatchesRegexForm},
+	"Not":                ValidatorDefinition{MakeNotValidator, NotForm},
+	"OneOf":              ValidatorDefinition{MakeOneOfValidator, OneOfForm},
+	"Or":                 ValidatorDefinition{MakeOrValidator, OrForm},
+	"HashPassword":       ValidatorDefinition{MakeHashPasswordValidator, HashPasswordForm},
+	"Pseudonymize":       ValidatorDefinition{MakePseudonymizeValidator, PseudonymizeForm},
+	"TruncateIP":         ValidatorDefinition{MakeTruncateIPValidator, TruncateIPForm},
+	"GeneralizeDate":     ValidatorDefinition{MakeGeneralizeDateValidator, GeneralizeDateForm},
+	"GeneralizeNumber":   ValidatorDefinition{MakeGeneralizeNumberValidator, GeneralizeNumberForm},
+	"RetainEmailDomain":  ValidatorDefinition{MakeRetainEmailDomainValidator, RetainEmailDomainForm},
+	"Switch":             ValidatorDefinition{MakeSwitchValidator, SwitchForm},
 }
@@ -0,0 +1,112 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+// ValidationWarning is a non-fatal message about a validated field, e.g. the
+// use of a deprecated field name or a value that was modified.
+type ValidationWarning struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationResult contains the validated values, the validation error (if
+// any) and the warnings that were emitted during validation.
+type ValidationResult struct {
+	Values   map[string]interface{} `json:"values,omitempty"`
+	Error    error                  `json:"-"`
+	Warnings []ValidationWarning    `json:"warnings,omitempty"`
+}
+
+func (r *ValidationResult) Valid() bool {
+	return r.Error == nil
+}
+
+// Errors returns the field errors of the validation error (if any)
+func (r *ValidationResult) Errors() map[string]interface{} {
+	if formError, ok := r.Error.(*FormError); ok {
+		if errors, ok := formError.Data().(map[string]interface{}); ok {
+			return errors
+		}
+	}
+	return nil
+}
+
+const warningsContextKey = "_warnings"
+
+// collects warnings for the field that is currently validated, prefix is
+// the path of the (nested) form that is validated.
+type warningCollector struct {
+	prefix   string
+	field    string
+	warnings *[]ValidationWarning
+}
+
+func (c *warningCollector) add(field, message string) {
+	*c.warnings = append(*c.warnings, ValidationWarning{
+		Field:   joinConfigPath(c.prefix, field),
+		Message: message,
+	})
+}
+
+// returns a collector for a form that is nested in the current field
+func (c *warningCollector) nested() *warningCollector {
+	return &warningCollector{
+		prefix:   joinConfigPath(c.prefix, c.field),
+		warnings: c.warnings,
+	}
+}
+
+// AddWarning adds a warning for the field that is currently validated. It
+// can be called by context validators and does nothing if warnings are not
+// collected (i.e. if the form was not validated via ValidateWithResult).
+func AddWarning(context map[string]interface{}, message string) {
+	if collector, ok := context[warningsContextKey].(*warningCollector); ok {
+		collector.add(collector.field, message)
+	}
+}
+
+func (f *Form) validateWithResult(inputs map[string]interface{}, update bool, context map[string]interface{}) *ValidationResult {
+
+	warnings := []ValidationWarning{}
+
+	// we copy the context as we modify it
+	resultContext := map[string]interface{}{}
+
+	for key, value := range context {
+		resultContext[key] = value
+	}
+
+	resultContext[warningsContextKey] = &warningCollector{warnings: &warnings}
+
+	values, err := f.validate(inputs, update, resultContext)
+
+	return &ValidationResult{
+		Values:   values,
+		Error:    err,
+		Warnings: warnings,
+	}
+}
+
+// ValidateWithResult validates the inputs and returns the values, the error
+// and all warnings emitted during validation.
+func (f *Form) ValidateWithResult(inputs map[string]interface{}, context map[string]interface{}) *ValidationResult {
+	return f.validateWithResult(inputs, false, context)
+}
+
+// ValidateUpdateWithResult works like ValidateWithResult but skips missing
+// fields, like ValidateUpdate.
+func (f *Form) ValidateUpdateWithResult(inputs map[string]interface{}, context map[string]interface{}) *ValidationResult {
+	return f.validateWithResult(inputs, true, context)
+}
@@ -0,0 +1,153 @@
+// KIProtect Go-Helpers - Golang Utility Functions
+// Copyright (C) 2019-2024  KIProtect GmbH (HRB 208395B) - Germany
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the 3-Clause BSD License.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// license for more details.
+//
+// You should have received a copy of the 3-Clause BSD License
+// along with this program.  If not, see <https://opensource.org/licenses/BSD-3-Clause>.
+
+package forms
+
+import (
+	"testing"
+)
+
+// truncates strings and emits a warning if it does so
+type truncateString struct {
+	Length int
+}
+
+func (t truncateString) Validate(input interface{}, values map[string]interface{}) (interface{}, error) {
+	return t.ValidateWithContext(input, values, nil)
+}
+
+func (t truncateString) ValidateWithContext(input interface{}, values map[string]interface{}, context map[string]interface{}) (interface{}, error) {
+	if str, ok := input.(string); ok && len(str) > t.Length {
+		AddWarning(context, "value was truncated")
+		return str[:t.Length], nil
+	}
+	return input, nil
+}
+
+func TestValidationWarnings(t *testing.T) {
+
+	addressForm := &Form{
+		Fields: []Field{
+			{
+				Name:       "street",
+				Validators: []Validator{IsString{}, truncateString{Length: 5}},
+			},
+		},
+	}
+
+	form := &Form{
+		Fields: []Field{
+			{
+				Name:       "name",
+				Aliases:    []string{"fullName"},
+				Validators: []Validator{IsString{}, truncateString{Length: 5}},
+			},
+			{
+				Name:       "nickname",
+				Deprecated: true,
+				Validators: []Validator{IsOptional{}, IsString{}},
+			},
+			{
+				Name:       "address",
+				Validators: []Validator{IsOptional{}, IsStringMap{Form: addressForm}},
+			},
+		},
+	}
+
+	context := map[string]interface{}{"foo": "bar"}
+
+	result := form.ValidateWithResult(map[string]interface{}{
+		"fullName": "Maximilian",
+		"nickname": "max",
+		"address":  map[string]interface{}{"street": "Main Street"},
+	}, context)
+
+	if !result.Valid() {
+		t.Fatalf("expected no error but got %v", result.Error)
+	}
+
+	if result.Values["name"] != "Maxim" {
+		t.Fatalf("expected a truncated name but got %v", result.Values["name"])
+	}
+
+	expected := map[string]bool{
+		"fullName":       true,
+		"name":           true,
+		"nickname":       true,
+		"address.street": true,
+	}
+
+	if len(result.Warnings) != len(expected) {
+		t.Fatalf("expected %d warnings but got %v", len(expected), result.Warnings)
+	}
+
+	for _, warning := range result.Warnings {
+		if !expected[warning.Field] {
+			t.Fatalf("unexpected warning: %v", warning)
+		}
+	}
+
+	if _, ok := context[warningsContextKey]; ok {
+		t.Fatalf("the context must not be modified")
+	}
+
+	result = form.ValidateWithResult(map[string]interface{}{"name": 4}, nil)
+
+	if result.Valid() {
+		t.Fatalf("expected an error")
+	} else if _, ok := result.Errors()["name"]; !ok {
+		t.Fatalf("expected an error for the name but got %v", result.Errors())
+	}
+
+	listForm := &Form{
+		Fields: []Field{
+			{
+				Name: "items",
+				Validators: []Validator{IsList{Validators: []Validator{IsStringMap{Form: &Form{
+					Fields: []Field{
+						{
+							Name:       "title",
+							Aliases:    []string{"name"},
+							Validators: []Validator{IsString{}},
+						},
+					},
+				}}}}},
+			},
+		},
+	}
+
+	result = listForm.ValidateWithResult(map[string]interface{}{
+		"items": []interface{}{
+			map[string]interface{}{"name": "foo"},
+			map[string]interface{}{"title": "bar"},
+			map[string]interface{}{"name": "baz"},
+		},
+	}, nil)
+
+	if !result.Valid() {
+		t.Fatalf("expected no error but got %v", result.Error)
+	}
+
+	// warnings of forms in lists contain the index of the list entry
+	if len(result.Warnings) != 2 || result.Warnings[0].Field != "items[0].name" || result.Warnings[1].Field != "items[2].name" {
+		t.Fatalf("expected warnings for items[0] and items[2] but got %v", result.Warnings)
+	}
+
+	// Validate remains backward compatible
+	if values, err := form.Validate(map[string]interface{}{"name": "Maximilian"}); err != nil {
+		t.Fatal(err)
+	} else if values["name"] != "Maxim" {
+		t.Fatalf("expected a truncated name")
+	}
+}